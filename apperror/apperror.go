@@ -0,0 +1,108 @@
+package apperror
+
+import (
+	"net/http"
+	"vakansii-back-go/dto"
+	"vakansii-back-go/i18n"
+)
+
+// Error ошибка приложения со стабильным машиночитаемым кодом
+// Status и Code попадают в ответ application/problem+json, описание для клиента берется
+// из каталога i18n по ключу error.<код> с параметрами Args, а исходная ошибка Err
+// только записывается в журнал
+type Error struct {
+	Status int
+	Code   string
+	Args   map[string]string
+	Errors []dto.FieldError
+	Err    error
+}
+
+// Error реализует интерфейс error
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Code + ": " + e.Err.Error()
+	}
+	return e.Code
+}
+
+// Unwrap возвращает исходную ошибку
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New создает ошибку с HTTP статусом и кодом
+func New(status int, code string) *Error {
+	return &Error{Status: status, Code: code}
+}
+
+// Wrap создает ошибку приложения, сохраняя исходную ошибку для журнала
+func Wrap(err error, status int, code string) *Error {
+	return &Error{Status: status, Code: code, Err: err}
+}
+
+// WithArgs добавляет параметры для подстановки в описание ошибки
+func (e *Error) WithArgs(args map[string]string) *Error {
+	e.Args = args
+	return e
+}
+
+// WithErrors добавляет к ошибке список ошибок полей
+func (e *Error) WithErrors(errors []dto.FieldError) *Error {
+	e.Errors = errors
+	return e
+}
+
+// BadRequest создает ошибку 400 для некорректного запроса
+func BadRequest(code string) *Error {
+	return New(http.StatusBadRequest, code)
+}
+
+// NotFound создает ошибку 404 для отсутствующей сущности
+func NotFound(code string) *Error {
+	return New(http.StatusNotFound, code)
+}
+
+// Unauthorized создает ошибку 401 для запроса без действительного токена
+func Unauthorized(code string) *Error {
+	return New(http.StatusUnauthorized, code)
+}
+
+// Forbidden создает ошибку 403 для операции без прав
+func Forbidden(code string) *Error {
+	return New(http.StatusForbidden, code)
+}
+
+// Conflict создает ошибку 409 для операции, противоречащей состоянию данных
+func Conflict(code string) *Error {
+	return New(http.StatusConflict, code)
+}
+
+// Problem тело ответа application/problem+json (RFC 9457, ранее RFC 7807)
+type Problem struct {
+	Type     string           `json:"type"`
+	Title    string           `json:"title"`
+	Status   int              `json:"status"`
+	Detail   string           `json:"detail,omitempty"`
+	Instance string           `json:"instance,omitempty"`
+	Code     string           `json:"code"`
+	Errors   []dto.FieldError `json:"errors,omitempty"`
+}
+
+// Problem формирует тело ответа для ошибки на языке lang
+func (e *Error) Problem(lang string, instance string) Problem {
+	var errors []dto.FieldError
+	for _, fieldError := range e.Errors {
+		errors = append(errors, fieldError.In(lang))
+	}
+
+	return Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(e.Status),
+		Status:   e.Status,
+		Detail:   i18n.T(lang, "error."+e.Code, e.Args),
+		Instance: instance,
+		Code:     e.Code,
+		Errors:   errors,
+	}
+}
@@ -4,16 +4,25 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 // Config структура конфигурации приложения
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	CORS     CORSConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	CORS      CORSConfig
 	RateLimit RateLimitConfig
+	JWT       JWTConfig
+	Mail      MailConfig
+	Account   AccountConfig
+	Scheduler SchedulerConfig
+	Cache     CacheConfig
+	I18n      I18nConfig
+	Vacancy   VacancyConfig
+	Admin     AdminConfig
 }
 
 // ServerConfig конфигурация сервера
@@ -43,6 +52,67 @@ type RateLimitConfig struct {
 	Window   int // в секундах
 }
 
+// JWTConfig конфигурация JWT токенов доступа и refresh токенов
+type JWTConfig struct {
+	Issuer          string
+	KeyID           string            // идентификатор активного ключа подписи (заголовок kid)
+	Secret          string            // активный ключ подписи HS256
+	PreviousKeys    map[string]string // прежние ключи, которыми еще можно проверять токены
+	AccessTokenTTL  int               // в секундах
+	RefreshTokenTTL int               // в секундах
+}
+
+// MailConfig конфигурация отправки писем
+type MailConfig struct {
+	Driver   string // "smtp" или "log"
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	LogPath  string // файл для драйвера log, пусто - stdout
+}
+
+// AccountConfig конфигурация подтверждения email и восстановления пароля
+type AccountConfig struct {
+	AppURL               string // адрес фронтенда для ссылок в письмах
+	EmailVerificationTTL int    // в секундах
+	PasswordResetTTL     int    // в секундах
+}
+
+// SchedulerConfig конфигурация фоновых задач
+type SchedulerConfig struct {
+	VacancyInterval      int // в секундах, период публикации и снятия вакансий по расписанию
+	PurgeInterval        int // в секундах, период очистки корзины вакансий
+	VacancyRetentionDays int // сколько дней удаленная вакансия хранится в корзине, 0 отключает очистку
+}
+
+// CacheConfig политики Cache-Control для публичных маршрутов чтения
+// Пустое значение отключает заголовок
+type CacheConfig struct {
+	VacancyList   string
+	VacancyView   string
+	VacancySearch string
+}
+
+// I18nConfig конфигурация языка сообщений API
+type I18nConfig struct {
+	DefaultLanguage string // язык, если Accept-Language не передан или не поддерживается
+}
+
+// VacancyConfig конфигурация списков вакансий
+type VacancyConfig struct {
+	// FilterFields ключи additional_fields, доступные для фильтрации и сортировки, и их типы:
+	// string, number, bool или array (массив строк)
+	FilterFields map[string]string
+	MaxPageSize  int // наибольшее значение per_page в списке и поиске вакансий
+}
+
+// AdminConfig начальная настройка администраторов
+type AdminConfig struct {
+	Email string // email зарегистрированного пользователя, который получает роль администратора при запуске
+}
+
 // Load загружает конфигурацию из .env файла
 func Load() *Config {
 	// Загружаем .env файл
@@ -70,6 +140,48 @@ func Load() *Config {
 			Requests: getEnvAsInt("RATE_LIMIT_REQUESTS", 100),
 			Window:   getEnvAsInt("RATE_LIMIT_WINDOW", 3600),
 		},
+		JWT: JWTConfig{
+			Issuer:          getEnv("JWT_ISSUER", "vakansii-back-go"),
+			KeyID:           getEnv("JWT_KEY_ID", "default"),
+			Secret:          getEnv("JWT_SECRET", ""),
+			PreviousKeys:    getEnvAsMap("JWT_PREVIOUS_KEYS", ""),
+			AccessTokenTTL:  getEnvAsInt("JWT_ACCESS_TTL", 900),
+			RefreshTokenTTL: getEnvAsInt("JWT_REFRESH_TTL", 2592000),
+		},
+		Mail: MailConfig{
+			Driver:   getEnv("MAIL_DRIVER", "log"),
+			Host:     getEnv("MAIL_HOST", "localhost"),
+			Port:     getEnv("MAIL_PORT", "25"),
+			Username: getEnv("MAIL_USERNAME", ""),
+			Password: getEnv("MAIL_PASSWORD", ""),
+			From:     getEnv("MAIL_FROM", "noreply@localhost"),
+			LogPath:  getEnv("MAIL_LOG_PATH", ""),
+		},
+		Account: AccountConfig{
+			AppURL:               getEnv("APP_URL", "http://localhost:3000"),
+			EmailVerificationTTL: getEnvAsInt("EMAIL_VERIFICATION_TTL", 86400),
+			PasswordResetTTL:     getEnvAsInt("PASSWORD_RESET_TTL", 3600),
+		},
+		Scheduler: SchedulerConfig{
+			VacancyInterval:      getEnvAsPositiveInt("SCHEDULER_VACANCY_INTERVAL", 60),
+			PurgeInterval:        getEnvAsPositiveInt("SCHEDULER_PURGE_INTERVAL", 3600),
+			VacancyRetentionDays: getEnvAsInt("VACANCY_TRASH_RETENTION_DAYS", 30),
+		},
+		Cache: CacheConfig{
+			VacancyList:   getEnv("CACHE_CONTROL_VACANCY_LIST", "public, max-age=30"),
+			VacancyView:   getEnv("CACHE_CONTROL_VACANCY_VIEW", "no-cache"),
+			VacancySearch: getEnv("CACHE_CONTROL_VACANCY_SEARCH", "public, max-age=30"),
+		},
+		I18n: I18nConfig{
+			DefaultLanguage: getEnv("DEFAULT_LANGUAGE", "ru"),
+		},
+		Vacancy: VacancyConfig{
+			FilterFields: getEnvAsMap("VACANCY_FILTER_FIELDS", "city:string,remote:bool,employment_type:string,level:string,stack:array"),
+			MaxPageSize:  getEnvAsPositiveInt("VACANCY_MAX_PAGE_SIZE", 100),
+		},
+		Admin: AdminConfig{
+			Email: strings.TrimSpace(getEnv("ADMIN_EMAIL", "")),
+		},
 	}
 }
 
@@ -90,6 +202,29 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvAsPositiveInt получает переменную окружения как положительное целое
+// Ноль и отрицательные значения заменяются значением по умолчанию с предупреждением в журнале
+func getEnvAsPositiveInt(key string, defaultValue int) int {
+	value := getEnvAsInt(key, defaultValue)
+	if value <= 0 {
+		log.Printf("Warning: %s must be positive, using %d", key, defaultValue)
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvAsMap разбирает переменную окружения вида "key1:value1,key2:value2"
+func getEnvAsMap(key string, defaultValue string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(getEnv(key, defaultValue), ",") {
+		k, v, found := strings.Cut(strings.TrimSpace(pair), ":")
+		if found && k != "" && v != "" {
+			result[k] = v
+		}
+	}
+	return result
+}
+
 // GetDSN возвращает строку подключения к базе данных
 func (c *Config) GetDSN() string {
 	return c.Database.User + ":" + c.Database.Password + "@tcp(" +
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"net/http"
+	"vakansii-back-go/apperror"
+	"vakansii-back-go/middleware"
+	"vakansii-back-go/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccountController контроллер подтверждения email и восстановления пароля
+type AccountController struct {
+	service services.AccountService
+}
+
+// NewAccountController создает новый экземпляр контроллера учетных записей
+func NewAccountController(service services.AccountService) *AccountController {
+	return &AccountController{service: service}
+}
+
+// ResendVerification повторно отправляет письмо подтверждения email
+// POST /auth/verify-email/resend
+func (ac *AccountController) ResendVerification(c *gin.Context) {
+	result, err := ac.service.SendEmailVerification(middleware.CurrentUser(c))
+	ac.respond(c, result, err)
+}
+
+// VerifyEmail подтверждает email по токену из письма
+// POST /auth/verify-email
+func (ac *AccountController) VerifyEmail(c *gin.Context) {
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := ac.service.VerifyEmail(data)
+	ac.respond(c, result, err)
+}
+
+// ForgotPassword отправляет письмо со ссылкой для сброса пароля
+// POST /auth/password/forgot
+func (ac *AccountController) ForgotPassword(c *gin.Context) {
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := ac.service.RequestPasswordReset(data)
+	ac.respond(c, result, err)
+}
+
+// ResetPassword устанавливает новый пароль по токену из письма
+// POST /auth/password/reset
+func (ac *AccountController) ResetPassword(c *gin.Context) {
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := ac.service.ResetPassword(data)
+	ac.respond(c, result, err)
+}
+
+// respond отправляет результат операции над учетной записью
+// Внутренние ошибки передаются в ErrorHandler, который записывает их в журнал
+func (ac *AccountController) respond(c *gin.Context, result map[string]interface{}, err error) {
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Если в результате есть success: false, возвращаем 400
+	if success, ok := result["success"].(bool); ok && !success {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// bindData разбирает JSON тело запроса
+// При ошибке передает в обработчик ошибок 400 malformed_request без подробностей разбора
+func bindData(c *gin.Context) (map[string]interface{}, bool) {
+	var data map[string]interface{}
+	if err := c.ShouldBindJSON(&data); err != nil {
+		c.Error(apperror.Wrap(err, http.StatusBadRequest, "malformed_request"))
+		return nil, false
+	}
+	return data, true
+}
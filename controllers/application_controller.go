@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"vakansii-back-go/middleware"
+	"vakansii-back-go/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApplicationController контроллер для работы с откликами на вакансии
+type ApplicationController struct {
+	service services.ApplicationService
+}
+
+// NewApplicationController создает новый экземпляр контроллера откликов
+func NewApplicationController(service services.ApplicationService) *ApplicationController {
+	return &ApplicationController{service: service}
+}
+
+// Apply создает отклик на вакансию
+// POST /vacancy/:id/applications
+func (ac *ApplicationController) Apply(c *gin.Context) {
+	vacancyID, ok := parseID(c, "Неверный ID вакансии")
+	if !ok {
+		return
+	}
+
+	// Тело запроса необязательно: сопроводительное письмо можно не указывать
+	data := map[string]interface{}{}
+	if c.Request.ContentLength != 0 {
+		if data, ok = bindData(c); !ok {
+			return
+		}
+	}
+
+	result, err := ac.service.Apply(vacancyID, data, middleware.CurrentUser(c))
+	if err != nil {
+		respondApplicationError(c, err, "Ошибка при создании отклика")
+		return
+	}
+
+	// Если в результате есть success: false, возвращаем 400
+	if success, ok := result["success"].(bool); ok && !success {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// Withdraw отзывает отклик
+// DELETE /applications/:id
+func (ac *ApplicationController) Withdraw(c *gin.Context) {
+	id, ok := parseID(c, "Неверный ID отклика")
+	if !ok {
+		return
+	}
+
+	result, err := ac.service.Withdraw(id, middleware.CurrentUser(c))
+	ac.respond(c, result, err, "Ошибка при отзыве отклика")
+}
+
+// VacancyApplications получает отклики на вакансию
+// GET /vacancy/:id/applications
+func (ac *ApplicationController) VacancyApplications(c *gin.Context) {
+	vacancyID, ok := parseID(c, "Неверный ID вакансии")
+	if !ok {
+		return
+	}
+
+	result, err := ac.service.GetVacancyApplications(vacancyID, c.Query("status"), pageParam(c), middleware.CurrentUser(c))
+	ac.respond(c, result, err, "Ошибка при получении списка откликов")
+}
+
+// MyApplications получает отклики текущего пользователя
+// GET /me/applications
+func (ac *ApplicationController) MyApplications(c *gin.Context) {
+	result, err := ac.service.GetUserApplications(middleware.CurrentUser(c), pageParam(c))
+	ac.respond(c, result, err, "Ошибка при получении списка откликов")
+}
+
+// ChangeStatus переводит отклик в новый статус
+// PUT /applications/:id/status
+func (ac *ApplicationController) ChangeStatus(c *gin.Context) {
+	id, ok := parseID(c, "Неверный ID отклика")
+	if !ok {
+		return
+	}
+
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := ac.service.ChangeStatus(id, data, middleware.CurrentUser(c))
+	ac.respond(c, result, err, "Ошибка при изменении статуса отклика")
+}
+
+// respond отправляет результат операции над откликом
+func (ac *ApplicationController) respond(c *gin.Context, result map[string]interface{}, err error, errorMessage string) {
+	if err != nil {
+		respondApplicationError(c, err, errorMessage)
+		return
+	}
+
+	// Если в результате есть success: false, возвращаем 400
+	if success, ok := result["success"].(bool); ok && !success {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// respondApplicationError преобразует ошибку сервиса откликов в HTTP ответ
+func respondApplicationError(c *gin.Context, err error, errorMessage string) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"message": "Не найдено",
+		})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"message": "Недостаточно прав",
+		})
+	case errors.Is(err, services.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{
+			"success": false,
+			"message": "Вы уже откликнулись на эту вакансию",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": errorMessage,
+		})
+	}
+}
+
+// parseID разбирает параметр :id, отвечая 400 при ошибке
+func parseID(c *gin.Context, message string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"message": message,
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// pageParam получает номер страницы из запроса
+func pageParam(c *gin.Context) int {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	if page > 10000 {
+		page = 10000
+	}
+	return page
+}
@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"vakansii-back-go/middleware"
+	"vakansii-back-go/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompanyController контроллер для работы с компаниями
+type CompanyController struct {
+	service services.CompanyService
+}
+
+// NewCompanyController создает новый экземпляр контроллера компаний
+func NewCompanyController(service services.CompanyService) *CompanyController {
+	return &CompanyController{service: service}
+}
+
+// Index получает список компаний с пагинацией
+// GET /company
+func (cc *CompanyController) Index(c *gin.Context) {
+	result, err := cc.service.GetCompanyList(pageParam(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Ошибка при получении списка компаний",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// View получает компанию по ID
+// GET /company/:id
+func (cc *CompanyController) View(c *gin.Context) {
+	id, ok := parseID(c, "Неверный ID компании")
+	if !ok {
+		return
+	}
+
+	company, err := cc.service.GetCompanyByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Ошибка при получении компании",
+		})
+		return
+	}
+
+	if company == nil {
+		respondCompanyError(c, services.ErrNotFound, "")
+		return
+	}
+
+	c.JSON(http.StatusOK, company)
+}
+
+// Create создает компанию
+// POST /company
+func (cc *CompanyController) Create(c *gin.Context) {
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := cc.service.CreateCompany(data, middleware.CurrentUser(c))
+	if err != nil {
+		respondCompanyError(c, err, "Ошибка при создании компании")
+		return
+	}
+
+	// Если в результате есть success: false, возвращаем 400
+	if success, ok := result["success"].(bool); ok && !success {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// Update обновляет компанию
+// PUT /company/:id
+func (cc *CompanyController) Update(c *gin.Context) {
+	id, ok := parseID(c, "Неверный ID компании")
+	if !ok {
+		return
+	}
+
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := cc.service.UpdateCompany(id, data, middleware.CurrentUser(c))
+	cc.respond(c, result, err, "Ошибка при обновлении компании")
+}
+
+// Delete удаляет компанию
+// DELETE /company/:id
+func (cc *CompanyController) Delete(c *gin.Context) {
+	id, ok := parseID(c, "Неверный ID компании")
+	if !ok {
+		return
+	}
+
+	result, err := cc.service.DeleteCompany(id, middleware.CurrentUser(c))
+	cc.respond(c, result, err, "Ошибка при удалении компании")
+}
+
+// Vacancies получает вакансии компании
+// GET /company/:id/vacancies
+func (cc *CompanyController) Vacancies(c *gin.Context) {
+	id, ok := parseID(c, "Неверный ID компании")
+	if !ok {
+		return
+	}
+
+	result, err := cc.service.GetCompanyVacancies(id, pageParam(c))
+	cc.respond(c, result, err, "Ошибка при получении списка вакансий")
+}
+
+// Members получает участников компании
+// GET /company/:id/members
+func (cc *CompanyController) Members(c *gin.Context) {
+	id, ok := parseID(c, "Неверный ID компании")
+	if !ok {
+		return
+	}
+
+	result, err := cc.service.GetMembers(id, middleware.CurrentUser(c))
+	cc.respond(c, result, err, "Ошибка при получении участников компании")
+}
+
+// AddMember добавляет участника компании
+// POST /company/:id/members
+func (cc *CompanyController) AddMember(c *gin.Context) {
+	id, ok := parseID(c, "Неверный ID компании")
+	if !ok {
+		return
+	}
+
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := cc.service.AddMember(id, data, middleware.CurrentUser(c))
+	cc.respond(c, result, err, "Ошибка при добавлении участника компании")
+}
+
+// RemoveMember исключает участника компании
+// DELETE /company/:id/members/:user_id
+func (cc *CompanyController) RemoveMember(c *gin.Context) {
+	id, ok := parseID(c, "Неверный ID компании")
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"message": "Неверный ID пользователя",
+		})
+		return
+	}
+
+	result, err := cc.service.RemoveMember(id, uint(userID), middleware.CurrentUser(c))
+	cc.respond(c, result, err, "Ошибка при исключении участника компании")
+}
+
+// respond отправляет результат операции над компанией
+func (cc *CompanyController) respond(c *gin.Context, result map[string]interface{}, err error, errorMessage string) {
+	if err != nil {
+		respondCompanyError(c, err, errorMessage)
+		return
+	}
+
+	// Если в результате есть success: false, возвращаем 400
+	if success, ok := result["success"].(bool); ok && !success {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// respondCompanyError преобразует ошибку сервиса компаний в HTTP ответ
+func respondCompanyError(c *gin.Context, err error, errorMessage string) {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"message": "Компания не найдена",
+		})
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"message": "Недостаточно прав для управления компанией",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": errorMessage,
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondCached отправляет JSON с заголовками ETag и Last-Modified
+// или 304 Not Modified, если копия клиента актуальна.
+// Если etag пустой, используется слабый ETag по хешу тела ответа,
+// нулевой lastModified означает ответ без Last-Modified
+func respondCached(c *gin.Context, body interface{}, etag string, lastModified time.Time) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if etag == "" {
+		sum := sha256.Sum256(payload)
+		etag = `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	}
+
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request, etag, lastModified) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", payload)
+}
+
+// notModified проверяет условия If-None-Match и If-Modified-Since
+// If-Modified-Since учитывается только при отсутствии If-None-Match (RFC 9110, 13.2.2)
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		if strings.TrimSpace(header) == "*" {
+			return true
+		}
+		for _, tag := range strings.Split(header, ",") {
+			if weakETag(tag) == weakETag(etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := r.Header.Get("If-Modified-Since"); header != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(header)
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+// weakETag приводит ETag к виду для слабого сравнения
+func weakETag(tag string) string {
+	return strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+}
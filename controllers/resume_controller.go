@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"vakansii-back-go/middleware"
+	"vakansii-back-go/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResumeController контроллер для работы с резюме
+type ResumeController struct {
+	service services.ResumeService
+}
+
+// NewResumeController создает новый экземпляр контроллера резюме
+func NewResumeController(service services.ResumeService) *ResumeController {
+	return &ResumeController{service: service}
+}
+
+// Index получает список резюме с пагинацией
+// GET /resume
+func (rc *ResumeController) Index(c *gin.Context) {
+	sortBy := c.DefaultQuery("sort", "updated_at")
+	sortOrder := c.DefaultQuery("order", "desc")
+
+	result, err := rc.service.GetResumeList(pageParam(c), sortBy, sortOrder)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Ошибка при получении списка резюме",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// View получает конкретное резюме по ID
+// GET /resume/:id
+func (rc *ResumeController) View(c *gin.Context) {
+	id, ok := parseID(c, "Неверный ID резюме")
+	if !ok {
+		return
+	}
+
+	// Получаем параметр fields
+	fieldsParam := c.Query("fields")
+	var fields []string
+	if fieldsParam != "" {
+		fields = strings.Split(fieldsParam, ",")
+		// Ограничиваем количество полей до 10
+		if len(fields) > 10 {
+			fields = fields[:10]
+		}
+	}
+
+	result, err := rc.service.GetResumeByID(id, fields, middleware.CurrentUser(c))
+	if errors.Is(err, services.ErrForbidden) {
+		respondResumeForbidden(c)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Ошибка при получении резюме",
+		})
+		return
+	}
+
+	if result == nil {
+		respondResumeNotFound(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Create создает новое резюме
+// POST /resume
+func (rc *ResumeController) Create(c *gin.Context) {
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := rc.service.CreateResume(data, middleware.CurrentUser(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Ошибка при создании резюме",
+		})
+		return
+	}
+
+	// Если в результате есть success: false, возвращаем 400
+	if success, ok := result["success"].(bool); ok && !success {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// Update обновляет существующее резюме
+// PUT /resume/:id
+func (rc *ResumeController) Update(c *gin.Context) {
+	id, ok := parseID(c, "Неверный ID резюме")
+	if !ok {
+		return
+	}
+
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := rc.service.UpdateResume(id, data, middleware.CurrentUser(c))
+	if errors.Is(err, services.ErrForbidden) {
+		respondResumeForbidden(c)
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		respondResumeNotFound(c)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Ошибка при обновлении резюме",
+		})
+		return
+	}
+
+	// Если в результате есть success: false, возвращаем 400
+	if success, ok := result["success"].(bool); ok && !success {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Delete удаляет резюме
+// DELETE /resume/:id
+func (rc *ResumeController) Delete(c *gin.Context) {
+	id, ok := parseID(c, "Неверный ID резюме")
+	if !ok {
+		return
+	}
+
+	result, err := rc.service.DeleteResume(id, middleware.CurrentUser(c))
+	if errors.Is(err, services.ErrForbidden) {
+		respondResumeForbidden(c)
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		respondResumeNotFound(c)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Ошибка при удалении резюме",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Search выполняет полнотекстовый поиск резюме
+// GET /resume/search
+func (rc *ResumeController) Search(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"message": "Поисковый запрос не может быть пустым",
+		})
+		return
+	}
+
+	sortOrder := c.DefaultQuery("sort", "relevance")
+
+	result, err := rc.service.SearchResumes(query, pageParam(c), sortOrder)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Ошибка при поиске резюме",
+		})
+		return
+	}
+
+	// Если в результате есть success: false, возвращаем 400
+	if success, ok := result["success"].(bool); ok && !success {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// MyResumes получает резюме текущего пользователя
+// GET /me/resumes
+func (rc *ResumeController) MyResumes(c *gin.Context) {
+	result, err := rc.service.GetUserResumes(middleware.CurrentUser(c), pageParam(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Ошибка при получении списка резюме",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// respondResumeForbidden отвечает 403, если у пользователя нет прав на резюме
+func respondResumeForbidden(c *gin.Context) {
+	c.JSON(http.StatusForbidden, gin.H{
+		"success": false,
+		"message": "Недостаточно прав для доступа к резюме",
+	})
+}
+
+// respondResumeNotFound отвечает 404, если резюме не существует
+func respondResumeNotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, gin.H{
+		"success": false,
+		"message": "Резюме не найдено",
+	})
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"vakansii-back-go/apperror"
+	"vakansii-back-go/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleController контроллер администрирования ролей пользователей
+type RoleController struct {
+	service services.RoleService
+}
+
+// NewRoleController создает новый экземпляр контроллера ролей
+func NewRoleController(service services.RoleService) *RoleController {
+	return &RoleController{service: service}
+}
+
+// Index получает список ролей
+// GET /admin/roles
+func (rc *RoleController) Index(c *gin.Context) {
+	roles, err := rc.service.GetRoleList()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Ошибка при получении списка ролей",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": roles})
+}
+
+// Grant назначает пользователю роль
+// POST /admin/users/:id/roles
+func (rc *RoleController) Grant(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"message": "Неверный ID пользователя",
+		})
+		return
+	}
+
+	var data struct {
+		Role string `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&data); err != nil || strings.TrimSpace(data.Role) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"message": "Роль обязательна",
+		})
+		return
+	}
+
+	result, err := rc.service.GrantRole(uint(userID), strings.TrimSpace(data.Role))
+	rc.respond(c, result, err)
+}
+
+// Revoke отзывает у пользователя роль
+// DELETE /admin/users/:id/roles/:role
+func (rc *RoleController) Revoke(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"message": "Неверный ID пользователя",
+		})
+		return
+	}
+
+	result, err := rc.service.RevokeRole(uint(userID), c.Param("role"))
+	rc.respond(c, result, err)
+}
+
+// respond отправляет результат операции над ролями
+// Ошибки передаются в ErrorHandler, попытка отозвать роль у последнего администратора возвращает 409
+func (rc *RoleController) respond(c *gin.Context, result map[string]interface{}, err error) {
+	if err != nil {
+		if errors.Is(err, services.ErrLastAdmin) {
+			err = apperror.Wrap(err, http.StatusConflict, "last_admin")
+		}
+		c.Error(err)
+		return
+	}
+
+	// Если в результате есть success: false, возвращаем 404
+	if success, ok := result["success"].(bool); ok && !success {
+		c.JSON(http.StatusNotFound, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"net/http"
+	"vakansii-back-go/middleware"
+	"vakansii-back-go/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserController контроллер для регистрации и аутентификации пользователей
+type UserController struct {
+	service services.UserService
+}
+
+// NewUserController создает новый экземпляр контроллера пользователей
+func NewUserController(service services.UserService) *UserController {
+	return &UserController{service: service}
+}
+
+// Register регистрирует нового пользователя
+// POST /auth/register
+func (uc *UserController) Register(c *gin.Context) {
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := uc.service.Register(data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Если в результате есть success: false, возвращаем 400
+	if success, ok := result["success"].(bool); ok && !success {
+		c.JSON(http.StatusBadRequest, result)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// Login аутентифицирует пользователя и выдает пару токенов
+// POST /auth/login
+func (uc *UserController) Login(c *gin.Context) {
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := uc.service.Login(data, clientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Если в результате есть success: false, возвращаем 401
+	if success, ok := result["success"].(bool); ok && !success {
+		c.JSON(http.StatusUnauthorized, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Refresh обменивает refresh токен на новую пару токенов
+// POST /auth/refresh
+func (uc *UserController) Refresh(c *gin.Context) {
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := uc.service.Refresh(data, clientInfo(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Если в результате есть success: false, возвращаем 401
+	if success, ok := result["success"].(bool); ok && !success {
+		c.JSON(http.StatusUnauthorized, result)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Logout отзывает refresh токен
+// POST /auth/logout
+func (uc *UserController) Logout(c *gin.Context) {
+	data, ok := bindData(c)
+	if !ok {
+		return
+	}
+
+	result, err := uc.service.Logout(data)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Me возвращает текущего аутентифицированного пользователя
+// GET /auth/me
+func (uc *UserController) Me(c *gin.Context) {
+	c.JSON(http.StatusOK, middleware.CurrentUser(c))
+}
+
+// clientInfo собирает сведения об устройстве клиента для привязки refresh токена
+func clientInfo(c *gin.Context) services.ClientInfo {
+	return services.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
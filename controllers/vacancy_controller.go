@@ -1,9 +1,16 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
+	"vakansii-back-go/apperror"
+	"vakansii-back-go/dto"
+	"vakansii-back-go/i18n"
+	"vakansii-back-go/middleware"
+	"vakansii-back-go/models"
 	"vakansii-back-go/services"
 
 	"github.com/gin-gonic/gin"
@@ -19,220 +26,417 @@ func NewVacancyController(service services.VacancyService) *VacancyController {
 	return &VacancyController{service: service}
 }
 
-// Index получает список вакансий с пагинацией
-// GET /vacancy
+// Index получает список вакансий с пагинацией и фильтрами
+// GET /vacancy?salary_min=&salary_max=&created_after=&created_before=&title=&af.<ключ>=&sort=&page=|cursor=&per_page=&fields=
 func (vc *VacancyController) Index(c *gin.Context) {
 	// Получаем параметры запроса
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	sortBy := c.DefaultQuery("sort", "created_at")
 	sortOrder := c.DefaultQuery("order", "desc")
 
-	// Валидация page
-	if page < 1 {
-		page = 1
-	}
-	if page > 10000 {
-		page = 10000
+	var filter dto.VacancyFilterQuery
+	if !bindFilter(c, &filter) {
+		return
 	}
 
-	result, err := vc.service.GetVacancyList(page, sortBy, sortOrder)
+	result, err := vc.service.GetVacancyList(&filter, listPage(c), sortBy, sortOrder, fieldsParam(c), contentLanguages(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Ошибка при получении списка вакансий",
-			"error":   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	// Время изменения берется по всем вакансиям, а не по странице: удаление вакансии из списка
+	// не меняет время изменения оставшихся
+	lastModified, err := vc.service.ListModifiedAt()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	respondCached(c, result, "", lastModified)
 }
 
 // View получает конкретную вакансию по ID
 // GET /vacancy/:id
 func (vc *VacancyController) View(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Неверный ID вакансии",
-		})
+	id, ok := vacancyIDParam(c)
+	if !ok {
 		return
 	}
 
-	// Получаем параметр fields
-	fieldsParam := c.Query("fields")
-	var fields []string
-	if fieldsParam != "" {
-		fields = strings.Split(fieldsParam, ",")
-		// Ограничиваем количество полей до 10
-		if len(fields) > 10 {
-			fields = fields[:10]
-		}
-	}
-
-	result, err := vc.service.GetVacancyByID(uint(id), fields)
+	result, vacancy, err := vc.service.GetVacancyByID(id, fieldsParam(c), contentLanguages(c), middleware.CurrentUser(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Ошибка при получении вакансии",
-			"error":   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
 	if result == nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"success": false,
-			"message": "Вакансия не найдена",
-		})
+		c.Error(vacancyError(services.ErrNotFound))
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	respondCached(c, result, vacancyViewETag(vacancy), vacancyModifiedAt(vacancy))
 }
 
 // Create создает новую вакансию
 // POST /vacancy
 func (vc *VacancyController) Create(c *gin.Context) {
-	var data map[string]interface{}
-	if err := c.ShouldBindJSON(&data); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Неверный формат данных",
-			"error":   err.Error(),
-		})
+	var request dto.CreateVacancyRequest
+	if !decodeRequest(c, &request) {
 		return
 	}
 
-	result, err := vc.service.CreateVacancy(data)
+	result, err := vc.service.CreateVacancy(&request, middleware.CurrentUser(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Ошибка при создании вакансии",
-			"error":   err.Error(),
-		})
-		return
-	}
-
-	// Если в результате есть success: false, возвращаем 400
-	if success, ok := result["success"].(bool); ok && !success {
-		c.JSON(http.StatusBadRequest, result)
+		c.Error(vacancyError(err))
 		return
 	}
 
+	result.Message = result.Message.In(middleware.Language(c))
+	c.Header("ETag", vacancyETag(result.Version))
 	c.JSON(http.StatusCreated, result)
 }
 
 // Update обновляет существующую вакансию
 // PUT /vacancy/:id
 func (vc *VacancyController) Update(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Неверный ID вакансии",
-		})
+	id, ok := vacancyIDParam(c)
+	if !ok {
+		return
+	}
+
+	var request dto.UpdateVacancyRequest
+	if !decodeRequest(c, &request) {
+		return
+	}
+
+	result, err := vc.service.UpdateVacancy(id, &request, middleware.CurrentUser(c), ifMatchVersions(c))
+	vc.respondUpdate(c, result, err)
+}
+
+// Patch частично изменяет вакансию
+// PATCH /vacancy/:id
+// Принимает application/merge-patch+json (RFC 7386) и application/json-patch+json (RFC 6902)
+func (vc *VacancyController) Patch(c *gin.Context) {
+	id, ok := vacancyIDParam(c)
+	if !ok {
 		return
 	}
 
-	var data map[string]interface{}
-	if err := c.ShouldBindJSON(&data); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Неверный формат данных",
-			"error":   err.Error(),
-		})
+	contentType := c.ContentType()
+	if contentType != services.PatchTypeMerge && contentType != services.PatchTypeJSON {
+		c.Header("Accept-Patch", services.PatchTypeMerge+", "+services.PatchTypeJSON)
+		c.Error(apperror.New(http.StatusUnsupportedMediaType, "unsupported_media_type").
+			WithArgs(map[string]string{"types": services.PatchTypeMerge + ", " + services.PatchTypeJSON}))
 		return
 	}
 
-	result, err := vc.service.UpdateVacancy(uint(id), data)
+	body, err := c.GetRawData()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Ошибка при обновлении вакансии",
-			"error":   err.Error(),
-		})
+		c.Error(apperror.Wrap(err, http.StatusBadRequest, "malformed_request"))
 		return
 	}
 
-	// Если в результате есть success: false, возвращаем 404
-	if success, ok := result["success"].(bool); ok && !success {
-		c.JSON(http.StatusNotFound, result)
+	result, err := vc.service.PatchVacancy(id, contentType, body, middleware.CurrentUser(c), ifMatchVersions(c))
+	vc.respondUpdate(c, result, err)
+}
+
+// respondUpdate формирует ответ на изменение вакансии
+func (vc *VacancyController) respondUpdate(c *gin.Context, result *dto.VacancyUpdatedResponse, err error) {
+	if err != nil {
+		c.Error(vacancyError(err))
 		return
 	}
 
+	result.Message = result.Message.In(middleware.Language(c))
+	c.Header("ETag", vacancyETag(result.Version))
 	c.JSON(http.StatusOK, result)
 }
 
 // Delete удаляет вакансию
 // DELETE /vacancy/:id
 func (vc *VacancyController) Delete(c *gin.Context) {
-	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Неверный ID вакансии",
-		})
+	id, ok := vacancyIDParam(c)
+	if !ok {
 		return
 	}
 
-	result, err := vc.service.DeleteVacancy(uint(id))
+	result, err := vc.service.DeleteVacancy(id, middleware.CurrentUser(c), ifMatchVersions(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Ошибка при удалении вакансии",
-			"error":   err.Error(),
-		})
+		c.Error(vacancyError(err))
 		return
 	}
 
-	// Если в результате есть success: false, возвращаем 404
-	if success, ok := result["success"].(bool); ok && !success {
-		c.JSON(http.StatusNotFound, result)
-		return
-	}
-
-	c.JSON(http.StatusOK, result)
+	c.JSON(http.StatusOK, localize(c, result))
 }
 
 // Search выполняет полнотекстовый поиск вакансий
-// GET /vacancy/search
+// GET /vacancy/search?q=&sort=&page=|cursor=&per_page=&fields=
 func (vc *VacancyController) Search(c *gin.Context) {
 	query := strings.TrimSpace(c.Query("q"))
 	if query == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"message": "Поисковый запрос не может быть пустым",
-		})
+		c.Error(apperror.BadRequest("empty_search_query"))
 		return
 	}
 
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	if page < 1 {
-		page = 1
+	sortOrder := c.DefaultQuery("sort", "relevance")
+
+	var filter dto.VacancyFilterQuery
+	if !bindFilter(c, &filter) {
+		return
 	}
-	if page > 10000 {
-		page = 10000
+
+	result, err := vc.service.SearchVacancies(query, &filter, listPage(c), sortOrder, fieldsParam(c), contentLanguages(c))
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
-	sortOrder := c.DefaultQuery("sort", "relevance")
+	// Время изменения берется по всем вакансиям, а не по странице: удаление вакансии из списка
+	// не меняет время изменения оставшихся
+	lastModified, err := vc.service.ListModifiedAt()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	respondCached(c, result, "", lastModified)
+}
 
-	result, err := vc.service.SearchVacancies(query, page, sortOrder)
+// MyVacancies получает вакансии текущего пользователя
+// GET /me/vacancies
+func (vc *VacancyController) MyVacancies(c *gin.Context) {
+	result, err := vc.service.GetUserVacancies(middleware.CurrentUser(c), pageParam(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"message": "Ошибка при поиске вакансий",
-			"error":   err.Error(),
-		})
+		c.Error(err)
 		return
 	}
 
-	// Если в результате есть success: false, возвращаем 400
-	if success, ok := result["success"].(bool); ok && !success {
-		c.JSON(http.StatusBadRequest, result)
+	c.JSON(http.StatusOK, result)
+}
+
+// Publish публикует вакансию
+// POST /vacancy/:id/publish
+func (vc *VacancyController) Publish(c *gin.Context) {
+	vc.changeStatus(c, models.VacancyStatusPublished)
+}
+
+// Pause приостанавливает публикацию вакансии
+// POST /vacancy/:id/pause
+func (vc *VacancyController) Pause(c *gin.Context) {
+	vc.changeStatus(c, models.VacancyStatusPaused)
+}
+
+// Close закрывает набор по вакансии
+// POST /vacancy/:id/close
+func (vc *VacancyController) Close(c *gin.Context) {
+	vc.changeStatus(c, models.VacancyStatusClosed)
+}
+
+// Archive переносит вакансию в архив
+// POST /vacancy/:id/archive
+func (vc *VacancyController) Archive(c *gin.Context) {
+	vc.changeStatus(c, models.VacancyStatusArchived)
+}
+
+// Trash получает вакансии из корзины
+// GET /vacancy/trash
+func (vc *VacancyController) Trash(c *gin.Context) {
+	result, err := vc.service.GetTrash(middleware.CurrentUser(c), pageParam(c))
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, result)
 }
+
+// Restore возвращает вакансию из корзины
+// POST /vacancy/:id/restore
+func (vc *VacancyController) Restore(c *gin.Context) {
+	id, ok := vacancyIDParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := vc.service.RestoreVacancy(id, middleware.CurrentUser(c))
+	if errors.Is(err, services.ErrNotFound) {
+		c.Error(apperror.Wrap(err, http.StatusNotFound, "vacancy_not_in_trash"))
+		return
+	}
+	if err != nil {
+		c.Error(vacancyError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, localize(c, result))
+}
+
+// changeStatus переводит вакансию в указанный статус
+func (vc *VacancyController) changeStatus(c *gin.Context, status string) {
+	id, ok := vacancyIDParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := vc.service.ChangeStatus(id, status, middleware.CurrentUser(c))
+	if err != nil {
+		c.Error(vacancyError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, localize(c, result))
+}
+
+// vacancyIDParam разбирает ID вакансии из пути запроса
+func vacancyIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Error(apperror.BadRequest("invalid_vacancy_id"))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// vacancyError уточняет коды доменных ошибок для операций с вакансией
+func vacancyError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		return apperror.Wrap(err, http.StatusNotFound, "vacancy_not_found")
+	case errors.Is(err, services.ErrForbidden):
+		return apperror.Wrap(err, http.StatusForbidden, "vacancy_forbidden")
+	case errors.Is(err, services.ErrPreconditionFailed):
+		return apperror.Wrap(err, http.StatusPreconditionFailed, "vacancy_version_mismatch")
+	case errors.Is(err, services.ErrConflict):
+		return apperror.Wrap(err, http.StatusConflict, "vacancy_modified")
+	default:
+		return err
+	}
+}
+
+// bindFilter разбирает фильтры вакансий из строки запроса, включая условия af.<ключ>
+func bindFilter(c *gin.Context, filter *dto.VacancyFilterQuery) bool {
+	if err := c.ShouldBindQuery(filter); err != nil {
+		c.Error(apperror.Wrap(err, http.StatusBadRequest, "malformed_request"))
+		return false
+	}
+
+	for name, values := range c.Request.URL.Query() {
+		key, ok := strings.CutPrefix(name, dto.AdditionalFieldPrefix)
+		if !ok {
+			continue
+		}
+		if filter.Fields == nil {
+			filter.Fields = make(map[string][]string)
+		}
+		filter.Fields[key] = append(filter.Fields[key], values...)
+	}
+	return true
+}
+
+// listPage разбирает параметры страницы публичного списка: ?cursor= для выборки по курсору или ?page=,
+// а также размер страницы ?per_page=. Неверный per_page заменяется размером по умолчанию
+func listPage(c *gin.Context) dto.PageQuery {
+	page := dto.PageQuery{Page: pageParam(c)}
+	if perPage, err := strconv.Atoi(c.Query("per_page")); err == nil && perPage > 0 {
+		page.PerPage = perPage
+	}
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		page.Cursor = &cursor
+	}
+	return page
+}
+
+// fieldsParam разбирает список полей ?fields=, не более 10 полей
+func fieldsParam(c *gin.Context) []string {
+	param := c.Query("fields")
+	if param == "" {
+		return nil
+	}
+	fields := strings.Split(param, ",")
+	if len(fields) > 10 {
+		fields = fields[:10]
+	}
+	return fields
+}
+
+// contentLanguages возвращает языки текста вакансий в порядке предпочтения клиента
+// Параметр ?lang= имеет приоритет над заголовком Accept-Language
+func contentLanguages(c *gin.Context) []string {
+	languages := i18n.Preferences(c.GetHeader("Accept-Language"))
+	if lang := strings.ToLower(strings.TrimSpace(c.Query("lang"))); lang != "" {
+		languages = append([]string{lang}, languages...)
+	}
+	return languages
+}
+
+// localize переводит сообщение результата операции на язык запроса
+func localize(c *gin.Context, result map[string]interface{}) map[string]interface{} {
+	if message, ok := result["message"].(i18n.Message); ok {
+		result["message"] = message.In(middleware.Language(c))
+	}
+	return result
+}
+
+// vacancyETag формирует значение ETag по версии вакансии
+func vacancyETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// vacancyViewETag формирует ETag представления вакансии: версия, язык текста и время изменения компании
+// Тексты вакансии зависят от Accept-Language, а изменение встроенной компании не меняет версию вакансии,
+// поэтому и язык, и компания входят в ETag
+func vacancyViewETag(vacancy *models.Vacancy) string {
+	tag := strconv.Itoa(vacancy.Version) + "-" + vacancy.Language
+	if vacancy.Company != nil {
+		tag += "-" + strconv.FormatInt(vacancy.Company.UpdatedAt.UnixMilli(), 36)
+	}
+	return `"` + tag + `"`
+}
+
+// vacancyModifiedAt возвращает время последнего изменения вакансии или встроенной в нее компании
+func vacancyModifiedAt(vacancy *models.Vacancy) time.Time {
+	if vacancy.Company != nil && vacancy.Company.UpdatedAt.After(vacancy.UpdatedAt) {
+		return vacancy.Company.UpdatedAt
+	}
+	return vacancy.UpdatedAt
+}
+
+// ifMatchVersions разбирает заголовок If-Match в список версий вакансии
+// Возвращает nil, если заголовок не передан или равен "*". Слабые и нераспознанные ETag пропускаются.
+// Изменение защищается по версии вакансии, а не по представлению: ответы на запись возвращают
+// ETag "<версия>", а из ETag представления (vacancyViewETag) берется только версия, поскольку
+// язык текста и компания не входят в изменяемое состояние вакансии
+func ifMatchVersions(c *gin.Context) []int {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	versions := []int{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		value, _, _ := strings.Cut(tag[1:len(tag)-1], "-")
+		if version, err := strconv.Atoi(value); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// decodeRequest разбирает JSON тело запроса в DTO
+// При ошибке разбора передает в обработчик ошибок 400 со списком ошибок полей
+func decodeRequest(c *gin.Context, request interface{}) bool {
+	err := dto.Decode(c.Request.Body, request, false)
+	if err == nil {
+		return true
+	}
+
+	appErr := apperror.Wrap(err, http.StatusBadRequest, "malformed_request")
+	var validationErr *dto.ValidationError
+	if errors.As(err, &validationErr) {
+		appErr.WithErrors(validationErr.Errors)
+	}
+	c.Error(appErr)
+	return false
+}
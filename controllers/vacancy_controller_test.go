@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"vakansii-back-go/apperror"
+	"vakansii-back-go/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIfMatchVersions(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []int
+	}{
+		{"missing", "", nil},
+		{"any", "*", nil},
+		{"write etag", `"4"`, []int{4}},
+		{"view etag", `"4-ru"`, []int{4}},
+		{"view etag with company", `"4-en-lz3k1"`, []int{4}},
+		{"several", `"3", "4-ru"`, []int{3, 4}},
+		{"weak etag", `W/"4"`, []int{}},
+		{"not a version", `"abc"`, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("PUT", "/vacancy/1", nil)
+			if tt.header != "" {
+				c.Request.Header.Set("If-Match", tt.header)
+			}
+
+			if got := ifMatchVersions(c); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ifMatchVersions() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVacancyErrorVersionConflicts(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{services.ErrPreconditionFailed, http.StatusPreconditionFailed, "vacancy_version_mismatch"},
+		{services.ErrConflict, http.StatusConflict, "vacancy_modified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantCode, func(t *testing.T) {
+			var appErr *apperror.Error
+			if !errors.As(vacancyError(tt.err), &appErr) {
+				t.Fatalf("vacancyError() = %v, want *apperror.Error", vacancyError(tt.err))
+			}
+			if appErr.Status != tt.wantStatus || appErr.Code != tt.wantCode {
+				t.Errorf("vacancyError() = %d %s, want %d %s", appErr.Status, appErr.Code, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestContentLanguages(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		header string
+		want   []string
+	}{
+		{"nothing", "", "", []string{}},
+		{"header", "", "en-US,ru;q=0.5", []string{"en", "ru"}},
+		{"lang parameter first", "?lang=EN", "ru", []string{"en", "ru"}},
+		{"lang parameter only", "?lang=uk", "", []string{"uk"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/vacancy"+tt.query, nil)
+			if tt.header != "" {
+				c.Request.Header.Set("Accept-Language", tt.header)
+			}
+
+			if got := contentLanguages(c); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("contentLanguages() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"vakansii-back-go/apperror"
+	"vakansii-back-go/middleware"
+	"vakansii-back-go/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VacancyRevisionController контроллер для работы с историей изменений вакансий
+type VacancyRevisionController struct {
+	service services.VacancyService
+}
+
+// NewVacancyRevisionController создает новый экземпляр контроллера ревизий вакансий
+func NewVacancyRevisionController(service services.VacancyService) *VacancyRevisionController {
+	return &VacancyRevisionController{service: service}
+}
+
+// Index получает список ревизий вакансии
+// GET /vacancy/:id/revisions
+func (rc *VacancyRevisionController) Index(c *gin.Context) {
+	id, ok := vacancyIDParam(c)
+	if !ok {
+		return
+	}
+
+	result, err := rc.service.GetRevisions(id, middleware.CurrentUser(c), pageParam(c))
+	if err != nil {
+		c.Error(revisionError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// View получает ревизию вакансии по номеру
+// GET /vacancy/:id/revisions/:rev
+func (rc *VacancyRevisionController) View(c *gin.Context) {
+	id, ok := vacancyIDParam(c)
+	if !ok {
+		return
+	}
+	rev, ok := revisionParam(c, c.Param("rev"))
+	if !ok {
+		return
+	}
+
+	revision, err := rc.service.GetRevision(id, rev, middleware.CurrentUser(c))
+	if err != nil {
+		c.Error(revisionError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, revision)
+}
+
+// Diff сравнивает две ревизии вакансии
+// GET /vacancy/:id/diff?from=1&to=2
+func (rc *VacancyRevisionController) Diff(c *gin.Context) {
+	id, ok := vacancyIDParam(c)
+	if !ok {
+		return
+	}
+	from, ok := revisionParam(c, c.Query("from"))
+	if !ok {
+		return
+	}
+
+	// Без параметра to ревизия сравнивается с текущим состоянием
+	to := 0
+	if c.Query("to") != "" {
+		if to, ok = revisionParam(c, c.Query("to")); !ok {
+			return
+		}
+	}
+
+	result, err := rc.service.DiffRevisions(id, from, to, middleware.CurrentUser(c))
+	if err != nil {
+		c.Error(revisionError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Revert возвращает вакансию к состоянию ревизии
+// POST /vacancy/:id/revisions/:rev/revert
+func (rc *VacancyRevisionController) Revert(c *gin.Context) {
+	id, ok := vacancyIDParam(c)
+	if !ok {
+		return
+	}
+	rev, ok := revisionParam(c, c.Param("rev"))
+	if !ok {
+		return
+	}
+
+	result, err := rc.service.RevertVacancy(id, rev, middleware.CurrentUser(c))
+	if err != nil {
+		c.Error(revisionError(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, localize(c, result))
+}
+
+// revisionParam разбирает номер ревизии
+func revisionParam(c *gin.Context, value string) (int, bool) {
+	rev, err := strconv.Atoi(value)
+	if err != nil || rev < 1 {
+		c.Error(apperror.BadRequest("invalid_revision"))
+		return 0, false
+	}
+	return rev, true
+}
+
+// revisionError уточняет коды доменных ошибок для операций с ревизиями
+// Отсутствие самой вакансии сообщается кодом vacancy_not_found
+func revisionError(err error) error {
+	if errors.Is(err, services.ErrRevisionNotFound) {
+		return apperror.Wrap(err, http.StatusNotFound, "revision_not_found")
+	}
+	return vacancyError(err)
+}
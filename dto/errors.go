@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"strings"
+	"vakansii-back-go/i18n"
+)
+
+// FieldError ошибка проверки отдельного поля запроса
+// Key и Args определяют сообщение в каталоге i18n, Message содержит текст на языке по умолчанию
+type FieldError struct {
+	Field   string            `json:"field"`
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Key     string            `json:"-"`
+	Args    map[string]string `json:"-"`
+}
+
+// NewFieldError создает ошибку поля с сообщением из каталога по ключу key
+func NewFieldError(field string, code string, key string, args map[string]string) FieldError {
+	return FieldError{
+		Field:   field,
+		Code:    code,
+		Message: i18n.T(i18n.DefaultLanguage(), key, args),
+		Key:     key,
+		Args:    args,
+	}
+}
+
+// In возвращает ошибку поля с сообщением на указанном языке
+func (e FieldError) In(lang string) FieldError {
+	if e.Key != "" {
+		e.Message = i18n.T(lang, e.Key, e.Args)
+	}
+	return e
+}
+
+// ValidationError список ошибок проверки полей запроса
+type ValidationError struct {
+	Errors []FieldError
+}
+
+// Error реализует интерфейс error
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldError := range e.Errors {
+		messages = append(messages, fieldError.Field+": "+fieldError.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
+
+// NewValidationError создает ошибку проверки одного поля
+// Сообщение берется из каталога по ключу validation.<поле>.<код>, а при его отсутствии - validation.<код>
+func NewValidationError(field string, code string) *ValidationError {
+	key := "validation." + field + "." + code
+	if field == "" || !i18n.Has(key) {
+		key = "validation." + code
+	}
+	return &ValidationError{Errors: []FieldError{NewFieldError(field, code, key, nil)}}
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "encoding/json"
+
+// Nullable поле запроса, различающее отсутствие значения и явный null
+// Set равен true, если поле было в теле запроса; Value равен nil для null
+type Nullable[T any] struct {
+	Set   bool
+	Value *T
+}
+
+// UnmarshalJSON вызывается только для присутствующих в теле полей
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if string(data) == "null" {
+		n.Value = nil
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	n.Value = &value
+	return nil
+}
+
+// NullableOf создает поле, заданное значением указателя
+// nil означает отсутствие поля
+func NullableOf[T any](value *T) Nullable[T] {
+	return Nullable[T]{Set: value != nil, Value: value}
+}
+
+// validationValue возвращает значение для проверки валидатором
+func (n Nullable[T]) validationValue() interface{} {
+	if n.Value == nil {
+		return nil
+	}
+	return *n.Value
+}
@@ -0,0 +1,10 @@
+package dto
+
+// PageQuery параметры страницы списка из строки запроса
+// Если передан cursor, страница выбирается по курсору (пустой курсор - первая страница), иначе по номеру Page.
+// PerPage - запрошенный размер страницы, 0 - размер по умолчанию
+type PageQuery struct {
+	Page    int
+	PerPage int
+	Cursor  *string
+}
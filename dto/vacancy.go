@@ -0,0 +1,114 @@
+package dto
+
+import (
+	"strings"
+	"vakansii-back-go/i18n"
+	"vakansii-back-go/models"
+)
+
+// VacancyTranslationRequest перевод названия и описания вакансии
+type VacancyTranslationRequest struct {
+	Title       string `json:"title" validate:"required,max=255"`
+	Description string `json:"description" validate:"required"`
+}
+
+// VacancyTranslationsRequest переводы вакансии по двухбуквенному коду языка (ISO 639-1)
+type VacancyTranslationsRequest map[string]VacancyTranslationRequest
+
+// CreateVacancyRequest запрос на создание вакансии
+// Language - язык title и description, по умолчанию язык API по умолчанию.
+// Зарплата задается вилкой salary_from - salary_to, достаточно одной границы. Currency - код ISO 4217, по умолчанию RUB
+type CreateVacancyRequest struct {
+	Title            string                     `json:"title" validate:"required,max=255"`
+	Description      string                     `json:"description" validate:"required"`
+	Language         string                     `json:"language" validate:"omitempty,len=2,alpha"`
+	Translations     VacancyTranslationsRequest `json:"translations" validate:"omitempty,dive,keys,len=2,alpha,endkeys"`
+	SalaryFrom       *int                       `json:"salary_from" validate:"omitnil,min=0"`
+	SalaryTo         *int                       `json:"salary_to" validate:"omitnil,min=0"`
+	Currency         string                     `json:"currency" validate:"omitempty,len=3,alpha"`
+	SalaryGross      *bool                      `json:"salary_gross"`
+	AdditionalFields models.JSONB               `json:"additional_fields"`
+	CompanyID        *uint                      `json:"company_id" validate:"omitnil,min=1"`
+	Status           string                     `json:"status" validate:"omitempty,oneof=draft published"`
+	PublishAt        *string                    `json:"publish_at" validate:"omitnil,datetime=2006-01-02T15:04:05Z07:00"`
+	ExpiresAt        *string                    `json:"expires_at" validate:"omitnil,datetime=2006-01-02T15:04:05Z07:00"`
+	ExpiresInDays    *int                       `json:"expires_in_days" validate:"omitnil,min=1,max=365"`
+}
+
+// UpdateVacancyRequest запрос на изменение вакансии
+// Отсутствующие поля не изменяются, null очищает необязательные поля.
+// Переданные translations заменяют все переводы вакансии
+type UpdateVacancyRequest struct {
+	Title            *string                     `json:"title" validate:"omitnil,min=1,max=255"`
+	Description      *string                     `json:"description" validate:"omitnil,min=1"`
+	Language         *string                     `json:"language" validate:"omitnil,len=2,alpha"`
+	Translations     *VacancyTranslationsRequest `json:"translations" validate:"omitnil,dive,keys,len=2,alpha,endkeys"`
+	SalaryFrom       Nullable[int]               `json:"salary_from" validate:"omitempty,min=0"`
+	SalaryTo         Nullable[int]               `json:"salary_to" validate:"omitempty,min=0"`
+	Currency         *string                     `json:"currency" validate:"omitnil,len=3,alpha"`
+	SalaryGross      Nullable[bool]              `json:"salary_gross"`
+	AdditionalFields Nullable[models.JSONB]      `json:"additional_fields"`
+	CompanyID        Nullable[uint]              `json:"company_id" validate:"omitempty,min=1"`
+	PublishAt        Nullable[string]            `json:"publish_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	ExpiresAt        Nullable[string]            `json:"expires_at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	ExpiresInDays    *int                        `json:"expires_in_days" validate:"omitnil,min=1,max=365"`
+}
+
+// VacancyDocument редактируемые поля вакансии целиком, результат применения PATCH
+type VacancyDocument struct {
+	Title            string                     `json:"title" validate:"required,max=255"`
+	Description      string                     `json:"description" validate:"required"`
+	Language         string                     `json:"language" validate:"required,len=2,alpha"`
+	Translations     VacancyTranslationsRequest `json:"translations" validate:"omitempty,dive,keys,len=2,alpha,endkeys"`
+	SalaryFrom       *int                       `json:"salary_from" validate:"omitnil,min=0"`
+	SalaryTo         *int                       `json:"salary_to" validate:"omitnil,min=0"`
+	Currency         string                     `json:"currency" validate:"required,len=3,alpha"`
+	SalaryGross      *bool                      `json:"salary_gross"`
+	AdditionalFields models.JSONB               `json:"additional_fields"`
+	CompanyID        *uint                      `json:"company_id" validate:"omitnil,min=1"`
+	PublishAt        *string                    `json:"publish_at" validate:"omitnil,datetime=2006-01-02T15:04:05Z07:00"`
+	ExpiresAt        *string                    `json:"expires_at" validate:"omitnil,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// Normalize приводит коды языков к нижнему регистру и очищает текст переводов от пробелов по краям
+func (t VacancyTranslationsRequest) Normalize() VacancyTranslationsRequest {
+	if t == nil {
+		return nil
+	}
+	result := make(VacancyTranslationsRequest, len(t))
+	for locale, translation := range t {
+		result[strings.ToLower(strings.TrimSpace(locale))] = VacancyTranslationRequest{
+			Title:       strings.TrimSpace(translation.Title),
+			Description: strings.TrimSpace(translation.Description),
+		}
+	}
+	return result
+}
+
+// Texts возвращает переводы в виде карты для модели вакансии
+func (t VacancyTranslationsRequest) Texts() map[string]models.VacancyText {
+	if len(t) == 0 {
+		return nil
+	}
+	result := make(map[string]models.VacancyText, len(t))
+	for locale, translation := range t {
+		result[locale] = models.VacancyText{Title: translation.Title, Description: translation.Description}
+	}
+	return result
+}
+
+// VacancyCreatedResponse ответ на создание вакансии
+type VacancyCreatedResponse struct {
+	Success bool         `json:"success"`
+	ID      uint         `json:"id"`
+	Status  string       `json:"status"`
+	Version int          `json:"version"`
+	Message i18n.Message `json:"message"`
+}
+
+// VacancyUpdatedResponse ответ на изменение вакансии
+type VacancyUpdatedResponse struct {
+	Success bool         `json:"success"`
+	Version int          `json:"version"`
+	Message i18n.Message `json:"message"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// AdditionalFieldPrefix префикс параметров строки запроса с условиями на ключи additional_fields
+const AdditionalFieldPrefix = "af."
+
+// VacancyFilterQuery фильтры списка и поиска вакансий из строки запроса
+// created_after включает указанный момент, created_before - нет.
+// salary_min и salary_max указываются в валюте currency (по умолчанию RUB) и сравниваются с вилкой вакансии по курсу.
+// Fields - условия af.<ключ>=[оператор:]значение, сгруппированные по ключу без префикса
+type VacancyFilterQuery struct {
+	SalaryMin     string              `form:"salary_min" json:"salary_min" validate:"omitempty,number,max=10"`
+	SalaryMax     string              `form:"salary_max" json:"salary_max" validate:"omitempty,number,max=10"`
+	Currency      string              `form:"currency" json:"currency" validate:"omitempty,len=3,alpha"`
+	CreatedAfter  string              `form:"created_after" json:"created_after" validate:"omitempty,timestamp"`
+	CreatedBefore string              `form:"created_before" json:"created_before" validate:"omitempty,timestamp"`
+	Title         string              `form:"title" json:"title" validate:"max=255"`
+	Fields        map[string][]string `form:"-" json:"-"`
+}
+
+// ParseTimestamp разбирает дату в формате YYYY-MM-DD (полночь UTC) или RFC 3339
+func ParseTimestamp(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
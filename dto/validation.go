@@ -0,0 +1,147 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validate общий экземпляр валидатора, имена полей берутся из json тегов
+var validate = newValidator()
+
+// newValidator настраивает валидатор
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if value, ok := field.Interface().(interface{ validationValue() interface{} }); ok {
+			return value.validationValue()
+		}
+		return nil
+	}, Nullable[string]{}, Nullable[uint]{}, Nullable[int]{})
+
+	// Дата в формате YYYY-MM-DD или RFC 3339
+	_ = v.RegisterValidation("timestamp", func(field validator.FieldLevel) bool {
+		_, err := ParseTimestamp(field.Field().String())
+		return err == nil
+	})
+
+	return v
+}
+
+// Validate проверяет структуру запроса по тегам validate
+// Возвращает *ValidationError со списком ошибок полей
+func Validate(request interface{}) error {
+	err := validate.Struct(request)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	result := &ValidationError{}
+	for _, fieldError := range validationErrors {
+		key, args := fieldMessage(fieldError)
+		result.Errors = append(result.Errors, NewFieldError(fieldPath(fieldError), fieldError.Tag(), key, args))
+	}
+	return result
+}
+
+// Decode разбирает JSON тело запроса в структуру
+// В строгом режиме неизвестные поля считаются ошибкой.
+// Ошибки разбора возвращаются как *ValidationError с указанием поля, если его удалось определить
+func Decode(body io.Reader, request interface{}, strict bool) error {
+	decoder := json.NewDecoder(body)
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+
+	err := decoder.Decode(request)
+	if err == nil {
+		return nil
+	}
+
+	var typeError *json.UnmarshalTypeError
+	var syntaxError *json.SyntaxError
+	switch {
+	case errors.As(err, &typeError):
+		fieldError := NewFieldError(typeError.Field, "type", "validation.type."+typeName(typeError.Type), nil)
+		return &ValidationError{Errors: []FieldError{fieldError}}
+	case errors.As(err, &syntaxError), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return NewValidationError("", "invalid_json")
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), "json: unknown field "))
+		return NewValidationError(field, "unknown_field")
+	default:
+		return NewValidationError("", "invalid")
+	}
+}
+
+// fieldPath возвращает путь к полю без имени корневой структуры, например translations[en].title
+func fieldPath(fieldError validator.FieldError) string {
+	_, path, found := strings.Cut(fieldError.Namespace(), ".")
+	if !found {
+		return fieldError.Field()
+	}
+	return path
+}
+
+// fieldMessage возвращает ключ сообщения каталога и параметры для правила проверки
+func fieldMessage(fieldError validator.FieldError) (string, map[string]string) {
+	isString := fieldError.Kind() == reflect.String
+	args := map[string]string{"param": fieldError.Param()}
+
+	switch fieldError.Tag() {
+	case "required", "datetime", "alpha", "number", "timestamp":
+		return "validation." + fieldError.Tag(), nil
+	case "len":
+		return "validation.len", args
+	case "min", "max":
+		if isString {
+			return "validation." + fieldError.Tag() + "_length", args
+		}
+		return "validation." + fieldError.Tag(), args
+	case "oneof":
+		args["param"] = strings.ReplaceAll(fieldError.Param(), " ", ", ")
+		return "validation.oneof", args
+	default:
+		return "validation.invalid", nil
+	}
+}
+
+// typeName возвращает название ожидаемого типа JSON значения для ключа сообщения
+func typeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "value"
+	}
+}
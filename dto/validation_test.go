@@ -0,0 +1,138 @@
+package dto
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fieldErrors возвращает ошибки полей из err в виде строк "поле/код: сообщение"
+func fieldErrors(t *testing.T, err error) []string {
+	t.Helper()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("error = %v, want *ValidationError", err)
+	}
+	var result []string
+	for _, fieldError := range validationErr.Errors {
+		result = append(result, fieldError.Field+"/"+fieldError.Code+": "+fieldError.Message)
+	}
+	return result
+}
+
+func TestValidate(t *testing.T) {
+	negative := -1
+	date := "2025-01-10"
+
+	tests := []struct {
+		name    string
+		request CreateVacancyRequest
+		want    []string
+	}{
+		{"valid", CreateVacancyRequest{Title: "Go developer", Description: "Text"}, nil},
+		{"required", CreateVacancyRequest{}, []string{
+			"title/required: Обязательное поле",
+			"description/required: Обязательное поле",
+		}},
+		{"string length", CreateVacancyRequest{Title: strings.Repeat("a", 256), Description: "Text"}, []string{
+			"title/max: Не более 255 символов",
+		}},
+		{"number range", CreateVacancyRequest{Title: "Go", Description: "Text", SalaryFrom: &negative}, []string{
+			"salary_from/min: Значение должно быть не меньше 0",
+		}},
+		{"oneof", CreateVacancyRequest{Title: "Go", Description: "Text", Status: "closed"}, []string{
+			"status/oneof: Допустимые значения: draft, published",
+		}},
+		{"datetime", CreateVacancyRequest{Title: "Go", Description: "Text", PublishAt: &date}, []string{
+			"publish_at/datetime: Дата должна быть в формате RFC 3339",
+		}},
+		{"translation key", CreateVacancyRequest{Title: "Go", Description: "Text", Translations: VacancyTranslationsRequest{
+			"eng": {Title: "Go", Description: "Text"},
+		}}, []string{
+			"translations[eng]/len: Длина должна быть ровно 2 символа",
+		}},
+		{"translation text", CreateVacancyRequest{Title: "Go", Description: "Text", Translations: VacancyTranslationsRequest{
+			"en": {Description: "Text"},
+		}}, []string{
+			"translations[en].title/required: Обязательное поле",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(&tt.request)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if got := fieldErrors(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() errors = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		strict bool
+		want   []string
+	}{
+		{"wrong type", `{"title": 5}`, false, []string{"title/type: Неверный тип значения, ожидается строка"}},
+		{"nested wrong type", `{"salary_from": "100"}`, false, []string{"salary_from/type: Неверный тип значения, ожидается целое число"}},
+		{"invalid json", `{"title": `, false, []string{"/invalid_json: Тело запроса не является корректным JSON"}},
+		{"empty body", ``, false, []string{"/invalid_json: Тело запроса не является корректным JSON"}},
+		{"unknown field in strict mode", `{"salary": 100}`, true, []string{"salary/unknown_field: Поле не может быть изменено"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request CreateVacancyRequest
+			err := Decode(strings.NewReader(tt.body), &request, tt.strict)
+			if got := fieldErrors(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() errors = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	var request CreateVacancyRequest
+	if err := Decode(strings.NewReader(`{"salary": 100}`), &request, false); err != nil {
+		t.Errorf("Decode() without strict mode error = %v", err)
+	}
+}
+
+func TestNewValidationError(t *testing.T) {
+	tests := []struct {
+		field string
+		code  string
+		want  string
+	}{
+		{"salary_to", "range", "salary_to/range: Верхняя граница зарплаты не может быть меньше нижней"},
+		{"title", "required", "title/required: Обязательное поле"},
+		{"", "invalid", "/invalid: Неверное значение"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field+"/"+tt.code, func(t *testing.T) {
+			got := fieldErrors(t, NewValidationError(tt.field, tt.code))
+			if !reflect.DeepEqual(got, []string{tt.want}) {
+				t.Errorf("NewValidationError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldErrorIn(t *testing.T) {
+	fieldError := NewFieldError("title", "max", "validation.max_length", map[string]string{"param": "255"})
+
+	if got, want := fieldError.In("en").Message, "Must be at most 255 characters"; got != want {
+		t.Errorf("In(en) message = %q, want %q", got, want)
+	}
+	if got, want := fieldError.Message, "Не более 255 символов"; got != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+}
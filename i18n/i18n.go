@@ -0,0 +1,156 @@
+package i18n
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Поддерживаемые языки сообщений API
+const (
+	LangRU = "ru"
+	LangEN = "en"
+)
+
+// Supported языки, для которых есть каталог сообщений
+var Supported = []string{LangRU, LangEN}
+
+// defaultLanguage язык, используемый без Accept-Language или при отсутствии перевода
+var defaultLanguage = LangRU
+
+// SetDefaultLanguage задает язык по умолчанию
+// Возвращает false, если язык не поддерживается
+func SetDefaultLanguage(lang string) bool {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if !slices.Contains(Supported, lang) {
+		return false
+	}
+	defaultLanguage = lang
+	return true
+}
+
+// DefaultLanguage возвращает язык по умолчанию
+func DefaultLanguage() string {
+	return defaultLanguage
+}
+
+// Has проверяет, есть ли сообщение с ключом в каталоге языка по умолчанию
+func Has(key string) bool {
+	_, ok := catalog[defaultLanguage][key]
+	return ok
+}
+
+// T возвращает сообщение по ключу на указанном языке
+// Если перевода нет, используется язык по умолчанию, а затем сам ключ.
+// Параметры подставляются вместо {имя} в тексте сообщения
+func T(lang string, key string, args map[string]string) string {
+	text, ok := catalog[lang][key]
+	if !ok {
+		if text, ok = catalog[defaultLanguage][key]; !ok {
+			return key
+		}
+	}
+
+	if len(args) == 0 {
+		return text
+	}
+	pairs := make([]string, 0, len(args)*2)
+	for name, value := range args {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// Preferences разбирает заголовок Accept-Language (RFC 9110, 12.5.4) в список языков
+// по убыванию веса q. Учитывается только основной подтег языка: en-US соответствует en
+func Preferences(header string) []string {
+	type candidate struct {
+		lang   string
+		weight float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+
+		weight := 1.0
+		if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if weight <= 0 {
+			continue
+		}
+
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		candidates = append(candidates, candidate{lang: primary, weight: weight})
+	}
+
+	// При равных весах сохраняется порядок из заголовка
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].weight > candidates[j].weight
+	})
+
+	languages := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if !slices.Contains(languages, c.lang) {
+			languages = append(languages, c.lang)
+		}
+	}
+	return languages
+}
+
+// Negotiate выбирает язык сообщений по заголовку Accept-Language
+// Если ни один из запрошенных языков не поддерживается, возвращается язык по умолчанию
+func Negotiate(header string) string {
+	for _, lang := range Preferences(header) {
+		if lang == "*" {
+			break
+		}
+		if slices.Contains(Supported, lang) {
+			return lang
+		}
+	}
+	return defaultLanguage
+}
+
+// Message сообщение для клиента, которое переводится на язык запроса
+// Без вызова In сообщение сериализуется на языке по умолчанию
+type Message struct {
+	Key  string
+	Args map[string]string
+	lang string
+}
+
+// NewMessage создает сообщение по ключу каталога
+func NewMessage(key string, args map[string]string) Message {
+	return Message{Key: key, Args: args}
+}
+
+// In возвращает сообщение, переведенное на указанный язык
+func (m Message) In(lang string) Message {
+	m.lang = lang
+	return m
+}
+
+// String возвращает текст сообщения
+func (m Message) String() string {
+	lang := m.lang
+	if lang == "" {
+		lang = defaultLanguage
+	}
+	return T(lang, m.Key, m.Args)
+}
+
+// MarshalJSON сериализует сообщение как строку
+func (m Message) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
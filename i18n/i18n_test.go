@@ -0,0 +1,123 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for _, lang := range Supported {
+		for _, other := range Supported {
+			for key := range catalog[lang] {
+				if _, ok := catalog[other][key]; !ok {
+					t.Errorf("key %q exists in %s but not in %s", key, lang, other)
+				}
+			}
+		}
+	}
+}
+
+func TestCatalogsHaveSameArgs(t *testing.T) {
+	placeholder := regexp.MustCompile(`\{\w+\}`)
+	for key, text := range catalog[LangRU] {
+		want := placeholder.FindAllString(text, -1)
+		got := placeholder.FindAllString(catalog[LangEN][key], -1)
+		if len(got) != len(want) {
+			t.Errorf("key %q: en placeholders %v, ru placeholders %v", key, got, want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	catalog["xx"] = map[string]string{"error.only_xx": "only xx"}
+	defer delete(catalog, "xx")
+
+	tests := []struct {
+		name string
+		lang string
+		key  string
+		args map[string]string
+		want string
+	}{
+		{"russian", LangRU, "error.not_found", nil, "Не найдено"},
+		{"english", LangEN, "error.not_found", nil, "Not found"},
+		{"unsupported language", "de", "error.not_found", nil, "Не найдено"},
+		{"missing key", LangEN, "error.no_such_code", nil, "error.no_such_code"},
+		{"own catalog", "xx", "error.only_xx", nil, "only xx"},
+		{"missing translation", "xx", "error.not_found", nil, "Не найдено"},
+		{"args", LangEN, "validation.max_length", map[string]string{"param": "10"}, "Must be at most 10 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := T(tt.lang, tt.key, tt.args); got != tt.want {
+				t.Errorf("T() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", LangRU},
+		{"en", LangEN},
+		{"en-US,en;q=0.9", LangEN},
+		{"de-DE, en;q=0.5", LangEN},
+		{"en;q=0.5, ru;q=0.8", LangRU},
+		{"de, fr", LangRU},
+		{"*", LangRU},
+		{"en;q=0", LangRU},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := Negotiate(tt.header); got != tt.want {
+				t.Errorf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetDefaultLanguage(t *testing.T) {
+	defer SetDefaultLanguage(LangRU)
+
+	if SetDefaultLanguage("de") {
+		t.Errorf("SetDefaultLanguage(de) = true, want false")
+	}
+	if !SetDefaultLanguage(" EN ") || DefaultLanguage() != LangEN {
+		t.Fatalf("SetDefaultLanguage(EN) did not change the default language")
+	}
+	if got := Negotiate("de"); got != LangEN {
+		t.Errorf("Negotiate(de) = %q, want %q", got, LangEN)
+	}
+	if got := NewMessage("error.not_found", nil).String(); got != "Not found" {
+		t.Errorf("message in default language = %q", got)
+	}
+}
+
+func TestPreferences(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{"", []string{}},
+		{"en-US,en;q=0.9,ru;q=0.8", []string{"en", "ru"}},
+		{"ru;q=0.5, de, en;q=0.7", []string{"de", "en", "ru"}},
+		{"fr, de", []string{"fr", "de"}},
+		{"en;q=0, ru", []string{"ru"}},
+		{"en;q=abc, ru", []string{"ru"}},
+		{"EN-gb", []string{"en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := Preferences(tt.header); !slices.Equal(got, tt.want) {
+				t.Errorf("Preferences(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package i18n
+
+// catalog сообщения API по языкам
+// Ключи error.* соответствуют кодам ошибок problem+json, validation.* - кодам ошибок полей
+var catalog = map[string]map[string]string{
+	LangRU: {
+		// Ошибки запросов
+		"error.invalid_vacancy_id":        "Неверный ID вакансии",
+		"error.invalid_revision":          "Неверный номер ревизии",
+		"error.malformed_request":         "Неверный формат данных",
+		"error.empty_search_query":        "Поисковый запрос не может быть пустым",
+		"error.unsupported_media_type":    "Поддерживаются только {types}",
+		"error.vacancy_forbidden":         "Недостаточно прав для изменения вакансии",
+		"error.vacancy_not_found":         "Вакансия не найдена",
+		"error.vacancy_not_in_trash":      "Вакансия в корзине не найдена",
+		"error.revision_not_found":        "Ревизия не найдена",
+		"error.vacancy_modified":          "Вакансия была изменена другим пользователем, повторите запрос",
+		"error.vacancy_version_mismatch":  "Вакансия была изменена, получите актуальную версию и повторите запрос",
+		"error.invalid_status_transition": "Недопустимый переход статуса",
+		"error.last_admin":                "Нельзя отозвать роль у последнего администратора",
+		"error.validation_failed":         "Ошибка проверки данных",
+		"error.not_found":                 "Не найдено",
+		"error.forbidden":                 "Недостаточно прав",
+		"error.unauthorized":              "Требуется авторизация",
+		"error.invalid_token":             "Неверный или просроченный токен",
+		"error.conflict":                  "Операция противоречит текущему состоянию данных",
+		"error.precondition_failed":       "Данные были изменены, получите актуальную версию и повторите запрос",
+		"error.internal_error":            "Внутренняя ошибка сервера",
+
+		// Ошибки полей
+		"validation.required":                    "Обязательное поле",
+		"validation.min":                         "Значение должно быть не меньше {param}",
+		"validation.min_length":                  "Не менее {param} символов",
+		"validation.max":                         "Значение должно быть не больше {param}",
+		"validation.max_length":                  "Не более {param} символов",
+		"validation.len":                         "Длина должна быть ровно {param} символа",
+		"validation.alpha":                       "Допустимы только буквы",
+		"validation.oneof":                       "Допустимые значения: {param}",
+		"validation.datetime":                    "Дата должна быть в формате RFC 3339",
+		"validation.timestamp":                   "Дата должна быть в формате YYYY-MM-DD или RFC 3339",
+		"validation.number":                      "Значение должно быть целым неотрицательным числом",
+		"validation.invalid":                     "Неверное значение",
+		"validation.invalid_json":                "Тело запроса не является корректным JSON",
+		"validation.unknown_field":               "Поле не может быть изменено",
+		"validation.type.string":                 "Неверный тип значения, ожидается строка",
+		"validation.type.integer":                "Неверный тип значения, ожидается целое число",
+		"validation.type.number":                 "Неверный тип значения, ожидается число",
+		"validation.type.boolean":                "Неверный тип значения, ожидается логическое значение",
+		"validation.type.object":                 "Неверный тип значения, ожидается объект",
+		"validation.type.array":                  "Неверный тип значения, ожидается массив",
+		"validation.type.value":                  "Неверный тип значения",
+		"validation.content_type":                "Неподдерживаемый тип содержимого \"{value}\"",
+		"validation.not_allowed":                 "Фильтрация и сортировка по этому полю недоступны",
+		"validation.operator":                    "Оператор не поддерживается для этого поля",
+		"validation.q.required":                  "Поисковый запрос не может быть пустым",
+		"validation.translations.base_language":  "Перевод не может быть на языке основного текста вакансии",
+		"validation.salary_max.range":            "Максимальная зарплата не может быть меньше минимальной",
+		"validation.salary_from.required":        "Укажите нижнюю или верхнюю границу зарплаты",
+		"validation.salary_to.range":             "Верхняя граница зарплаты не может быть меньше нижней",
+		"validation.currency.not_supported":      "Валюта не поддерживается: для нее нет курса",
+		"validation.created_before.range":        "Конец периода должен быть позже его начала",
+		"validation.cursor.invalid":              "Курсор недействителен или получен с другой сортировкой",
+		"validation.company_id.not_found":        "Компания не найдена",
+		"validation.company_id.not_member":       "Вы не состоите в этой компании",
+		"validation.expires_at.after_publish_at": "Дата окончания размещения должна быть позже даты публикации",
+		"validation.expires_at.expired":          "Срок размещения вакансии истек, измените expires_at",
+
+		// Ошибки операций JSON Patch
+		"validation.patch.missing_path":       "Операция {index} ({op}): отсутствует поле path",
+		"validation.patch.missing_value":      "Операция {index} ({op}): отсутствует поле value",
+		"validation.patch.missing_from":       "Операция {index} ({op}): отсутствует поле from",
+		"validation.patch.move_into_self":     "Операция {index} ({op}): нельзя переместить значение внутрь самого себя",
+		"validation.patch.test_failed":        "Операция {index} ({op}): значение не совпадает с ожидаемым",
+		"validation.patch.unknown_op":         "Операция {index}: неизвестная операция \"{value}\"",
+		"validation.patch.invalid_pointer":    "Операция {index} ({op}): путь \"{value}\" должен начинаться с /",
+		"validation.patch.key_not_found":      "Операция {index} ({op}): ключ \"{value}\" не найден",
+		"validation.patch.not_container":      "Операция {index} ({op}): значение по пути не является объектом или массивом",
+		"validation.patch.invalid_index":      "Операция {index} ({op}): неверный индекс массива \"{value}\"",
+		"validation.patch.index_out_of_range": "Операция {index} ({op}): индекс массива {value} вне границ",
+
+		// Результаты операций
+		"vacancy.created":        "Вакансия успешно создана",
+		"vacancy.updated":        "Вакансия успешно обновлена",
+		"vacancy.deleted":        "Вакансия перемещена в корзину",
+		"vacancy.status_changed": "Статус вакансии обновлен",
+		"vacancy.restored":       "Вакансия восстановлена",
+		"vacancy.reverted":       "Вакансия восстановлена из ревизии {revision}",
+	},
+	LangEN: {
+		// Ошибки запросов
+		"error.invalid_vacancy_id":        "Invalid vacancy ID",
+		"error.invalid_revision":          "Invalid revision number",
+		"error.malformed_request":         "Malformed request body",
+		"error.empty_search_query":        "Search query must not be empty",
+		"error.unsupported_media_type":    "Only {types} are supported",
+		"error.vacancy_forbidden":         "You are not allowed to modify this vacancy",
+		"error.vacancy_not_found":         "Vacancy not found",
+		"error.vacancy_not_in_trash":      "Vacancy not found in trash",
+		"error.revision_not_found":        "Revision not found",
+		"error.vacancy_modified":          "The vacancy was modified by another user, please retry",
+		"error.vacancy_version_mismatch":  "The vacancy has changed, fetch the current version and retry",
+		"error.invalid_status_transition": "Status transition is not allowed",
+		"error.last_admin":                "The admin role cannot be revoked from the last admin",
+		"error.validation_failed":         "Validation failed",
+		"error.not_found":                 "Not found",
+		"error.forbidden":                 "Forbidden",
+		"error.unauthorized":              "Authentication required",
+		"error.invalid_token":             "Invalid or expired token",
+		"error.conflict":                  "The operation conflicts with the current state of the data",
+		"error.precondition_failed":       "The data has changed, fetch the current version and retry",
+		"error.internal_error":            "Internal server error",
+
+		// Ошибки полей
+		"validation.required":                    "This field is required",
+		"validation.min":                         "Must be at least {param}",
+		"validation.min_length":                  "Must be at least {param} characters",
+		"validation.max":                         "Must be at most {param}",
+		"validation.max_length":                  "Must be at most {param} characters",
+		"validation.len":                         "Must be exactly {param} characters long",
+		"validation.alpha":                       "Only letters are allowed",
+		"validation.oneof":                       "Allowed values: {param}",
+		"validation.datetime":                    "Date must be in RFC 3339 format",
+		"validation.timestamp":                   "Date must be in YYYY-MM-DD or RFC 3339 format",
+		"validation.number":                      "Must be a non-negative integer",
+		"validation.invalid":                     "Invalid value",
+		"validation.invalid_json":                "Request body is not valid JSON",
+		"validation.unknown_field":               "This field cannot be changed",
+		"validation.type.string":                 "Invalid value type, expected a string",
+		"validation.type.integer":                "Invalid value type, expected an integer",
+		"validation.type.number":                 "Invalid value type, expected a number",
+		"validation.type.boolean":                "Invalid value type, expected a boolean",
+		"validation.type.object":                 "Invalid value type, expected an object",
+		"validation.type.array":                  "Invalid value type, expected an array",
+		"validation.type.value":                  "Invalid value type",
+		"validation.content_type":                "Unsupported content type \"{value}\"",
+		"validation.not_allowed":                 "Filtering and sorting by this field are not available",
+		"validation.operator":                    "This operator is not supported for the field",
+		"validation.q.required":                  "Search query must not be empty",
+		"validation.translations.base_language":  "A translation cannot use the vacancy's main language",
+		"validation.salary_max.range":            "Maximum salary cannot be less than minimum salary",
+		"validation.salary_from.required":        "Specify the lower or upper salary bound",
+		"validation.salary_to.range":             "The upper salary bound cannot be less than the lower bound",
+		"validation.currency.not_supported":      "Currency is not supported: no exchange rate is available",
+		"validation.created_before.range":        "The end of the period must be after its start",
+		"validation.cursor.invalid":              "The cursor is invalid or was issued for a different sort order",
+		"validation.company_id.not_found":        "Company not found",
+		"validation.company_id.not_member":       "You are not a member of this company",
+		"validation.expires_at.after_publish_at": "Expiration date must be after the publication date",
+		"validation.expires_at.expired":          "The vacancy has expired, change expires_at",
+
+		// Ошибки операций JSON Patch
+		"validation.patch.missing_path":       "Operation {index} ({op}): missing path",
+		"validation.patch.missing_value":      "Operation {index} ({op}): missing value",
+		"validation.patch.missing_from":       "Operation {index} ({op}): missing from",
+		"validation.patch.move_into_self":     "Operation {index} ({op}): cannot move a value into itself",
+		"validation.patch.test_failed":        "Operation {index} ({op}): value does not match",
+		"validation.patch.unknown_op":         "Operation {index}: unknown operation \"{value}\"",
+		"validation.patch.invalid_pointer":    "Operation {index} ({op}): path \"{value}\" must start with /",
+		"validation.patch.key_not_found":      "Operation {index} ({op}): key \"{value}\" not found",
+		"validation.patch.not_container":      "Operation {index} ({op}): value at path is not an object or array",
+		"validation.patch.invalid_index":      "Operation {index} ({op}): invalid array index \"{value}\"",
+		"validation.patch.index_out_of_range": "Operation {index} ({op}): array index {value} is out of range",
+
+		// Результаты операций
+		"vacancy.created":        "Vacancy created",
+		"vacancy.updated":        "Vacancy updated",
+		"vacancy.deleted":        "Vacancy moved to trash",
+		"vacancy.status_changed": "Vacancy status updated",
+		"vacancy.restored":       "Vacancy restored",
+		"vacancy.reverted":       "Vacancy restored from revision {revision}",
+	},
+}
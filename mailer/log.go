@@ -0,0 +1,40 @@
+package mailer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogSender записывает письма в файл или стандартный вывод вместо отправки
+// Используется при локальной разработке и в тестах
+type LogSender struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewLogSender создает отправителя, пишущего письма в файл
+// Если путь не указан, письма выводятся в stdout
+func NewLogSender(path string) (*LogSender, error) {
+	if path == "" {
+		return &LogSender{out: os.Stdout}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mail log: %w", err)
+	}
+	return &LogSender{out: file}, nil
+}
+
+// Send записывает письмо
+func (s *LogSender) Send(msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := fmt.Fprintf(s.out, "=== %s ===\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+	return err
+}
@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"fmt"
+	"vakansii-back-go/config"
+)
+
+// Message письмо для отправки
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender интерфейс отправки писем
+type Sender interface {
+	Send(msg Message) error
+}
+
+// New создает отправителя писем по конфигурации
+// Поддерживаются драйверы "smtp" и "log"
+func New(cfg config.MailConfig) (Sender, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPSender(cfg), nil
+	case "log", "":
+		return NewLogSender(cfg.LogPath)
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %s", cfg.Driver)
+	}
+}
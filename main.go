@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 	"vakansii-back-go/config"
 	"vakansii-back-go/controllers"
+	"vakansii-back-go/i18n"
+	"vakansii-back-go/mailer"
 	"vakansii-back-go/middleware"
 	"vakansii-back-go/migrations"
+	"vakansii-back-go/models"
 	"vakansii-back-go/repositories"
+	"vakansii-back-go/scheduler"
 	"vakansii-back-go/services"
 
 	"github.com/gin-contrib/cors"
@@ -21,7 +27,7 @@ func main() {
 	cfg := config.Load()
 
 	// Подключаемся к базе данных
-	db, err := gorm.Open(mysql.Open(cfg.GetDSN()), &gorm.Config{})
+	db, err := gorm.Open(mysql.Open(cfg.GetDSN()), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -30,6 +36,17 @@ func main() {
 	if err := migrations.Migrate(db); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
+	if err := migrations.BootstrapAdmin(db, cfg.Admin.Email); err != nil {
+		log.Fatalf("Failed to bootstrap admin: %v", err)
+	}
+
+	if cfg.JWT.Secret == "" {
+		log.Fatal("JWT_SECRET is not set")
+	}
+
+	if !i18n.SetDefaultLanguage(cfg.I18n.DefaultLanguage) {
+		log.Fatalf("Unsupported DEFAULT_LANGUAGE: %s", cfg.I18n.DefaultLanguage)
+	}
 
 	// Устанавливаем режим Gin
 	gin.SetMode(cfg.Server.Mode)
@@ -42,28 +59,169 @@ func main() {
 		AllowOrigins:     cfg.CORS.AllowOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
 		AllowHeaders:     []string{"*"},
+		ExposeHeaders:    []string{"ETag", "Last-Modified"},
 		AllowCredentials: false,
 		MaxAge:           86400,
 	}
 	r.Use(cors.New(corsConfig))
 
+	// Выбираем язык ответа и преобразуем ошибки обработчиков в ответы application/problem+json
+	r.Use(middleware.Locale())
+	r.Use(middleware.ErrorHandler())
+
 	// Добавляем rate limiter middleware
 	r.Use(middleware.RateLimiter(cfg.RateLimit.Requests, cfg.RateLimit.Window))
 
+	// Настраиваем отправку писем
+	mailSender, err := mailer.New(cfg.Mail)
+	if err != nil {
+		log.Fatalf("Failed to configure mailer: %v", err)
+	}
+
 	// Инициализируем слои
 	vacancyRepo := repositories.NewVacancyRepository(db)
-	vacancyService := services.NewVacancyService(vacancyRepo)
+	companyRepo := repositories.NewCompanyRepository(db)
+	vacancyRevisionRepo := repositories.NewVacancyRevisionRepository(db)
+	exchangeRateRepo := repositories.NewExchangeRateRepository(db)
+	vacancyService := services.NewVacancyService(cfg.Vacancy, vacancyRepo, companyRepo, vacancyRevisionRepo, exchangeRateRepo)
 	vacancyController := controllers.NewVacancyController(vacancyService)
+	vacancyRevisionController := controllers.NewVacancyRevisionController(vacancyService)
+
+	userRepo := repositories.NewUserRepository(db)
+	roleRepo := repositories.NewRoleRepository(db)
+	refreshTokenRepo := repositories.NewRefreshTokenRepository(db)
+	tokenService := services.NewTokenService(cfg.JWT, refreshTokenRepo, userRepo)
+
+	userTokenRepo := repositories.NewUserTokenRepository(db)
+	accountService := services.NewAccountService(cfg.Account, userRepo, userTokenRepo, tokenService, mailSender)
+	accountController := controllers.NewAccountController(accountService)
+
+	userService := services.NewUserService(userRepo, roleRepo, tokenService, accountService)
+	userController := controllers.NewUserController(userService)
+
+	applicationRepo := repositories.NewApplicationRepository(db)
+	applicationService := services.NewApplicationService(applicationRepo, vacancyRepo, companyRepo)
+	applicationController := controllers.NewApplicationController(applicationService)
+
+	resumeRepo := repositories.NewResumeRepository(db)
+	resumeService := services.NewResumeService(resumeRepo)
+	resumeController := controllers.NewResumeController(resumeService)
+
+	companyService := services.NewCompanyService(companyRepo, vacancyRepo, userRepo)
+	companyController := controllers.NewCompanyController(companyService)
+
+	roleService := services.NewRoleService(roleRepo, userRepo)
+	roleController := controllers.NewRoleController(roleService)
+
+	// Middleware аутентификации по Bearer токену
+	authRequired := middleware.Auth(userService)
+	authOptional := middleware.OptionalAuth(userService)
+
+	// Проверки ролей
+	employerOnly := middleware.RequireRole(models.RoleEmployer, models.RoleAdmin)
+	vacancyManagers := middleware.RequireRole(models.RoleEmployer, models.RoleModerator, models.RoleAdmin)
+	adminOnly := middleware.RequireRole(models.RoleAdmin)
+	candidateOnly := middleware.RequireRole(models.RoleCandidate)
+
+	// Запускаем фоновые задачи
+	jobs := scheduler.New()
+	jobs.Add("vacancy-schedule", time.Duration(cfg.Scheduler.VacancyInterval)*time.Second, func(ctx context.Context) error {
+		published, expired, err := vacancyService.ApplySchedule(time.Now())
+		if published > 0 || expired > 0 {
+			log.Printf("Vacancy schedule: %d published, %d expired", published, expired)
+		}
+		return err
+	})
+	if cfg.Scheduler.VacancyRetentionDays > 0 {
+		retention := time.Duration(cfg.Scheduler.VacancyRetentionDays) * 24 * time.Hour
+		jobs.Add("vacancy-purge", time.Duration(cfg.Scheduler.PurgeInterval)*time.Second, func(ctx context.Context) error {
+			purged, err := vacancyService.PurgeDeleted(time.Now().Add(-retention))
+			if purged > 0 {
+				log.Printf("Vacancy purge: %d removed from trash", purged)
+			}
+			return err
+		})
+	}
+	jobs.Start(context.Background())
 
 	// Настраиваем роуты
+	authGroup := r.Group("/auth")
+	{
+		authGroup.POST("/register", userController.Register)
+		authGroup.POST("/login", userController.Login)
+		authGroup.POST("/refresh", userController.Refresh)
+		authGroup.POST("/logout", userController.Logout)
+		authGroup.GET("/me", authRequired, userController.Me)
+		authGroup.POST("/verify-email", accountController.VerifyEmail)
+		authGroup.POST("/verify-email/resend", authRequired, accountController.ResendVerification)
+		authGroup.POST("/password/forgot", accountController.ForgotPassword)
+		authGroup.POST("/password/reset", accountController.ResetPassword)
+	}
+
 	vacancyGroup := r.Group("/vacancy")
 	{
-		vacancyGroup.GET("", vacancyController.Index)
-		vacancyGroup.GET("/search", vacancyController.Search)
-		vacancyGroup.GET("/:id", vacancyController.View)
-		vacancyGroup.POST("", vacancyController.Create)
-		vacancyGroup.PUT("/:id", vacancyController.Update)
-		vacancyGroup.DELETE("/:id", vacancyController.Delete)
+		vacancyGroup.GET("", middleware.CacheControl(cfg.Cache.VacancyList), vacancyController.Index)
+		vacancyGroup.GET("/search", middleware.CacheControl(cfg.Cache.VacancySearch), vacancyController.Search)
+		vacancyGroup.GET("/trash", authRequired, vacancyManagers, vacancyController.Trash)
+		vacancyGroup.GET("/:id", middleware.CacheControl(cfg.Cache.VacancyView), authOptional, vacancyController.View)
+		vacancyGroup.POST("", authRequired, employerOnly, vacancyController.Create)
+		vacancyGroup.PUT("/:id", authRequired, vacancyManagers, vacancyController.Update)
+		vacancyGroup.PATCH("/:id", authRequired, vacancyManagers, vacancyController.Patch)
+		vacancyGroup.DELETE("/:id", authRequired, vacancyManagers, vacancyController.Delete)
+		vacancyGroup.POST("/:id/publish", authRequired, vacancyManagers, vacancyController.Publish)
+		vacancyGroup.POST("/:id/pause", authRequired, vacancyManagers, vacancyController.Pause)
+		vacancyGroup.POST("/:id/close", authRequired, vacancyManagers, vacancyController.Close)
+		vacancyGroup.POST("/:id/archive", authRequired, vacancyManagers, vacancyController.Archive)
+		vacancyGroup.POST("/:id/restore", authRequired, vacancyManagers, vacancyController.Restore)
+		vacancyGroup.GET("/:id/revisions", authRequired, vacancyManagers, vacancyRevisionController.Index)
+		vacancyGroup.GET("/:id/revisions/:rev", authRequired, vacancyManagers, vacancyRevisionController.View)
+		vacancyGroup.POST("/:id/revisions/:rev/revert", authRequired, vacancyManagers, vacancyRevisionController.Revert)
+		vacancyGroup.GET("/:id/diff", authRequired, vacancyManagers, vacancyRevisionController.Diff)
+		vacancyGroup.POST("/:id/applications", authRequired, candidateOnly, applicationController.Apply)
+		vacancyGroup.GET("/:id/applications", authRequired, vacancyManagers, applicationController.VacancyApplications)
+	}
+
+	companyGroup := r.Group("/company")
+	{
+		companyGroup.GET("", companyController.Index)
+		companyGroup.GET("/:id", companyController.View)
+		companyGroup.GET("/:id/vacancies", companyController.Vacancies)
+		companyGroup.POST("", authRequired, employerOnly, companyController.Create)
+		companyGroup.PUT("/:id", authRequired, companyController.Update)
+		companyGroup.DELETE("/:id", authRequired, companyController.Delete)
+		companyGroup.GET("/:id/members", authRequired, companyController.Members)
+		companyGroup.POST("/:id/members", authRequired, companyController.AddMember)
+		companyGroup.DELETE("/:id/members/:user_id", authRequired, companyController.RemoveMember)
+	}
+
+	resumeGroup := r.Group("/resume", authRequired)
+	{
+		resumeGroup.GET("", employerOnly, resumeController.Index)
+		resumeGroup.GET("/search", employerOnly, resumeController.Search)
+		resumeGroup.GET("/:id", resumeController.View)
+		resumeGroup.POST("", candidateOnly, resumeController.Create)
+		resumeGroup.PUT("/:id", resumeController.Update)
+		resumeGroup.DELETE("/:id", resumeController.Delete)
+	}
+
+	applicationGroup := r.Group("/applications", authRequired)
+	{
+		applicationGroup.DELETE("/:id", candidateOnly, applicationController.Withdraw)
+		applicationGroup.PUT("/:id/status", vacancyManagers, applicationController.ChangeStatus)
+	}
+
+	meGroup := r.Group("/me", authRequired)
+	{
+		meGroup.GET("/vacancies", employerOnly, vacancyController.MyVacancies)
+		meGroup.GET("/applications", candidateOnly, applicationController.MyApplications)
+		meGroup.GET("/resumes", candidateOnly, resumeController.MyResumes)
+	}
+
+	adminGroup := r.Group("/admin", authRequired, adminOnly)
+	{
+		adminGroup.GET("/roles", roleController.Index)
+		adminGroup.POST("/users/:id/roles", roleController.Grant)
+		adminGroup.DELETE("/users/:id/roles/:role", roleController.Revoke)
 	}
 
 	// Запускаем сервер
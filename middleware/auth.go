@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"strings"
+	"vakansii-back-go/apperror"
+	"vakansii-back-go/models"
+	"vakansii-back-go/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserContextKey ключ, под которым аутентифицированный пользователь хранится в gin.Context
+const UserContextKey = "user"
+
+// Auth создает middleware, требующий заголовок Authorization: Bearer <token>
+func Auth(userService services.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			abortUnauthorized(c, "unauthorized")
+			return
+		}
+
+		user, err := userService.Authenticate(token)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		// Неизвестный токен или удаленный пользователь
+		if user == nil {
+			abortUnauthorized(c, "invalid_token")
+			return
+		}
+
+		c.Set(UserContextKey, user)
+		c.Next()
+	}
+}
+
+// OptionalAuth создает middleware, который определяет пользователя по токену, если он передан
+// Запросы без токена или с неверным токеном пропускаются анонимно
+func OptionalAuth(userService services.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token, ok := bearerToken(c.GetHeader("Authorization")); ok {
+			if user, err := userService.Authenticate(token); err == nil && user != nil {
+				c.Set(UserContextKey, user)
+			}
+		}
+		c.Next()
+	}
+}
+
+// CurrentUser возвращает аутентифицированного пользователя из контекста или nil
+func CurrentUser(c *gin.Context) *models.User {
+	value, exists := c.Get(UserContextKey)
+	if !exists {
+		return nil
+	}
+	user, _ := value.(*models.User)
+	return user
+}
+
+// bearerToken извлекает токен из значения заголовка Authorization
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// abortUnauthorized прерывает запрос с кодом 401, ответ формирует ErrorHandler
+func abortUnauthorized(c *gin.Context, code string) {
+	c.Header("WWW-Authenticate", "Bearer")
+	c.Error(apperror.Unauthorized(code))
+	c.Abort()
+}
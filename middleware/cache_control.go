@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControl создает middleware, задающий заголовок Cache-Control для маршрута
+// Политика применяется только к успешным ответам и 304, ошибки не кешируются.
+// Ответы на запросы с Authorization могут зависеть от пользователя, поэтому
+// для них общая политика заменяется на private, no-cache. Пустая политика отключает заголовок
+func CacheControl(policy string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if policy == "" {
+			c.Next()
+			return
+		}
+
+		value := policy
+		if c.GetHeader("Authorization") != "" {
+			value = "private, no-cache"
+		}
+
+		c.Writer.Header().Add("Vary", "Authorization")
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, policy: value}
+		c.Next()
+	}
+}
+
+// cacheControlWriter выставляет Cache-Control в момент выбора кода ответа
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	policy string
+}
+
+// WriteHeader добавляет Cache-Control для успешных ответов
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if code < http.StatusBadRequest {
+		w.Header().Set("Cache-Control", w.policy)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
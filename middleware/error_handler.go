@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"vakansii-back-go/apperror"
+	"vakansii-back-go/dto"
+	"vakansii-back-go/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType тип содержимого ответов с ошибкой
+const ProblemContentType = "application/problem+json"
+
+// ErrorHandler создает middleware, преобразующий ошибки из c.Error в ответ application/problem+json
+// Доменные ошибки сервисов получают стабильные коды, внутренние ошибки записываются в журнал
+// и отдаются клиенту без подробностей
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		appErr := toAppError(err)
+		if appErr.Status >= http.StatusInternalServerError {
+			log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		}
+
+		if c.Writer.Written() {
+			return
+		}
+
+		body, marshalErr := json.Marshal(appErr.Problem(Language(c), c.Request.URL.Path))
+		if marshalErr != nil {
+			log.Printf("Failed to encode problem response: %v", marshalErr)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		c.Data(appErr.Status, ProblemContentType, body)
+	}
+}
+
+// toAppError сопоставляет ошибку с кодом и HTTP статусом
+func toAppError(err error) *apperror.Error {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var validationErr *dto.ValidationError
+	if errors.As(err, &validationErr) {
+		return apperror.Wrap(err, http.StatusUnprocessableEntity, "validation_failed").
+			WithErrors(validationErr.Errors)
+	}
+
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		return apperror.Wrap(err, http.StatusNotFound, "not_found")
+	case errors.Is(err, services.ErrForbidden):
+		return apperror.Wrap(err, http.StatusForbidden, "forbidden")
+	case errors.Is(err, services.ErrInvalidTransition):
+		return apperror.Wrap(err, http.StatusConflict, "invalid_status_transition")
+	case errors.Is(err, services.ErrConflict):
+		return apperror.Wrap(err, http.StatusConflict, "conflict")
+	case errors.Is(err, services.ErrPreconditionFailed):
+		return apperror.Wrap(err, http.StatusPreconditionFailed, "precondition_failed")
+	default:
+		return apperror.Wrap(err, http.StatusInternalServerError, "internal_error")
+	}
+}
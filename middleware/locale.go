@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"vakansii-back-go/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LanguageContextKey ключ, под которым язык ответа хранится в gin.Context
+const LanguageContextKey = "lang"
+
+// Locale создает middleware, выбирающий язык ответа по заголовку Accept-Language
+// Выбранный язык возвращается в Content-Language, ответ зависит от Accept-Language
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+		c.Set(LanguageContextKey, lang)
+		c.Header("Content-Language", lang)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
+
+// Language возвращает язык ответа из контекста или язык по умолчанию
+func Language(c *gin.Context) string {
+	if lang := c.GetString(LanguageContextKey); lang != "" {
+		return lang
+	}
+	return i18n.DefaultLanguage()
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"vakansii-back-go/apperror"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole создает middleware, пропускающий только пользователей с одной из указанных ролей
+// Должен подключаться после Auth
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := CurrentUser(c)
+		if user == nil {
+			abortUnauthorized(c, "unauthorized")
+			return
+		}
+
+		if !user.HasRole(roles...) {
+			c.Error(apperror.Forbidden("forbidden"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
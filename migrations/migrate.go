@@ -1,6 +1,7 @@
 package migrations
 
 import (
+	"errors"
 	"fmt"
 	"vakansii-back-go/models"
 
@@ -11,16 +12,58 @@ import (
 func Migrate(db *gorm.DB) error {
 	fmt.Println("Running database migrations...")
 
+	// До появления статусов все вакансии были опубликованы
+	hadVacancyStatus := db.Migrator().HasColumn(&models.Vacancy{}, "status")
+	// До появления вилки зарплата вакансии хранилась одним числом в колонке salary
+	hadSingleSalary := db.Migrator().HasColumn(&models.Vacancy{}, "salary")
+	// До появления ролей таблицы user_role не было, роли существующим пользователям назначаются один раз
+	hadUserRoles := db.Migrator().HasTable("user_role")
+
 	// Автоматическая миграция для таблиц
 	err := db.AutoMigrate(
 		&models.Vacancy{},
 		&models.User{},
+		&models.Role{},
+		&models.RefreshToken{},
+		&models.UserToken{},
+		&models.Application{},
+		&models.Resume{},
+		&models.Company{},
+		&models.CompanyMember{},
+		&models.VacancyRevision{},
+		&models.VacancyTranslation{},
+		&models.ExchangeRate{},
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	if !hadVacancyStatus {
+		if err := db.Model(&models.Vacancy{}).Where("1 = 1").
+			UpdateColumn("status", models.VacancyStatusPublished).Error; err != nil {
+			return fmt.Errorf("failed to publish existing vacancies: %w", err)
+		}
+	}
+
+	if err := seedExchangeRates(db); err != nil {
+		return fmt.Errorf("failed to seed exchange rates: %w", err)
+	}
+
+	if hadSingleSalary {
+		if err := migrateSalaryRange(db); err != nil {
+			return fmt.Errorf("failed to migrate vacancy salaries: %w", err)
+		}
+	}
+
+	if err := seedRoles(db, !hadUserRoles); err != nil {
+		return fmt.Errorf("failed to seed roles: %w", err)
+	}
+
+	if err := dropLegacyAccessTokens(db); err != nil {
+		return fmt.Errorf("failed to drop legacy access tokens: %w", err)
+	}
+
 	// Создание FULLTEXT индексов для поиска (для MySQL)
 	if err := createFullTextIndexes(db); err != nil {
 		fmt.Printf("Warning: failed to create fulltext indexes: %v\n", err)
@@ -30,21 +73,140 @@ func Migrate(db *gorm.DB) error {
 	return nil
 }
 
-// createFullTextIndexes создает FULLTEXT индексы для таблицы vacancy
+// createFullTextIndexes создает FULLTEXT индексы для таблиц vacancy, vacancy_translation и resume
 func createFullTextIndexes(db *gorm.DB) error {
+	if err := createFullTextIndex(db, "vacancy", "idx_vacancy_fulltext", "title, description"); err != nil {
+		return err
+	}
+	if err := createFullTextIndex(db, "vacancy_translation", "idx_vacancy_translation_fulltext", "title, description"); err != nil {
+		return err
+	}
+	return createFullTextIndex(db, "resume", "idx_resume_fulltext", "title, summary, skills_text")
+}
+
+// createFullTextIndex создает FULLTEXT индекс, если он еще не существует
+func createFullTextIndex(db *gorm.DB, table string, index string, columns string) error {
 	// Проверяем, существует ли индекс
 	var count int64
-	db.Raw("SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS WHERE table_schema = DATABASE() AND table_name = 'vacancy' AND index_name = 'idx_vacancy_fulltext'").Scan(&count)
+	db.Raw("SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ?", table, index).Scan(&count)
 
 	if count == 0 {
 		// Создаем FULLTEXT индекс
-		if err := db.Exec("ALTER TABLE vacancy ADD FULLTEXT INDEX idx_vacancy_fulltext (title, description)").Error; err != nil {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD FULLTEXT INDEX %s (%s)", table, index, columns)).Error; err != nil {
 			return err
 		}
-		fmt.Println("FULLTEXT index created successfully")
+		fmt.Printf("FULLTEXT index %s created successfully\n", index)
 	} else {
-		fmt.Println("FULLTEXT index already exists")
+		fmt.Printf("FULLTEXT index %s already exists\n", index)
+	}
+
+	return nil
+}
+
+// seedExchangeRates добавляет курс базовой валюты, курсы остальных валют заполняются вне приложения
+func seedExchangeRates(db *gorm.DB) error {
+	base := models.ExchangeRate{Currency: models.BaseCurrency, Rate: 1}
+	return db.Where(models.ExchangeRate{Currency: base.Currency}).FirstOrCreate(&base).Error
+}
+
+// migrateSalaryRange переносит зарплату из колонки salary в вилку salary_from - salary_to
+// Прежние зарплаты указывались в базовой валюте
+func migrateSalaryRange(db *gorm.DB) error {
+	err := db.Exec(`UPDATE vacancy SET salary_from = salary, salary_to = salary, currency = ?
+		WHERE salary_from IS NULL AND salary_to IS NULL`, models.BaseCurrency).Error
+	if err != nil {
+		return err
+	}
+
+	if err := db.Migrator().DropColumn(&models.Vacancy{}, "salary"); err != nil {
+		return err
+	}
+	fmt.Println("Vacancy salaries migrated to salary ranges")
+
+	return nil
+}
+
+// seedRoles создает стандартные роли
+// backfill назначает роли пользователям, созданным до появления ролей. Выполняется только при создании
+// таблицы user_role, иначе при каждом запуске возвращались бы роли, снятые администратором
+func seedRoles(db *gorm.DB, backfill bool) error {
+	for _, role := range models.DefaultRoles {
+		if err := db.Where(models.Role{Name: role.Name}).FirstOrCreate(&role).Error; err != nil {
+			return err
+		}
+	}
+
+	// Владельцы вакансий становятся работодателями, остальные пользователи соискателями
+	if backfill {
+		err := db.Exec(`INSERT INTO user_role (user_id, role_id)
+			SELECT u.id, r.id FROM user u
+			JOIN role r ON r.name = IF(EXISTS (SELECT 1 FROM vacancy v WHERE v.user_id = u.id), ?, ?)
+			WHERE NOT EXISTS (SELECT 1 FROM user_role ur WHERE ur.user_id = u.id)`,
+			models.RoleEmployer, models.RoleCandidate).Error
+		if err != nil {
+			return err
+		}
+		fmt.Println("Roles assigned to existing users")
+	}
+
+	if !db.Migrator().HasColumn(&models.User{}, "admin") {
+		return nil
+	}
+
+	err := db.Exec(`INSERT IGNORE INTO user_role (user_id, role_id)
+		SELECT u.id, r.id FROM user u JOIN role r ON r.name = ? WHERE u.admin = 1`, models.RoleAdmin).Error
+	if err != nil {
+		return err
+	}
+
+	if err := db.Migrator().DropColumn(&models.User{}, "admin"); err != nil {
+		return err
+	}
+	fmt.Println("Legacy admin flags migrated to roles")
+
+	return nil
+}
+
+// BootstrapAdmin назначает роль администратора пользователю с указанным email
+// Так в новой базе появляется первый администратор, остальным роли назначаются через API.
+// Пустой email пропускается, отсутствие пользователя не считается ошибкой: он может зарегистрироваться
+// позже, и роль будет назначена при следующем запуске
+func BootstrapAdmin(db *gorm.DB, email string) error {
+	if email == "" {
+		return nil
+	}
+
+	var user models.User
+	err := db.Where("email = ?", email).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		fmt.Printf("Warning: admin user %s not found, register it and restart to grant the admin role\n", email)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	result := db.Exec(`INSERT IGNORE INTO user_role (user_id, role_id)
+		SELECT ?, r.id FROM role r WHERE r.name = ?`, user.ID, models.RoleAdmin)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		fmt.Printf("Admin role granted to %s\n", email)
+	}
+	return nil
+}
+
+// dropLegacyAccessTokens удаляет бессрочные токены user.access_token, замененные на JWT
+func dropLegacyAccessTokens(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&models.User{}, "access_token") {
+		return nil
+	}
+
+	if err := db.Migrator().DropColumn(&models.User{}, "access_token"); err != nil {
+		return err
 	}
+	fmt.Println("Legacy access tokens removed")
 
 	return nil
 }
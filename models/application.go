@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// Application модель отклика соискателя на вакансию
+type Application struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	VacancyID   uint      `gorm:"not null;uniqueIndex:idx_application_vacancy_user" json:"vacancy_id"`
+	Vacancy     *Vacancy  `gorm:"constraint:OnDelete:CASCADE" json:"vacancy,omitempty"`
+	UserID      uint      `gorm:"not null;index;uniqueIndex:idx_application_vacancy_user" json:"user_id"`
+	User        *User     `gorm:"constraint:OnDelete:CASCADE" json:"user,omitempty"`
+	CoverLetter string    `gorm:"type:text" json:"cover_letter"`
+	Status      string    `gorm:"type:varchar(16);default:new;not null;index" json:"status"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+const (
+	// ApplicationStatusNew новый отклик
+	ApplicationStatusNew = "new"
+	// ApplicationStatusReviewed отклик просмотрен работодателем
+	ApplicationStatusReviewed = "reviewed"
+	// ApplicationStatusInterview кандидат приглашен на собеседование
+	ApplicationStatusInterview = "interview"
+	// ApplicationStatusRejected кандидату отказано
+	ApplicationStatusRejected = "rejected"
+	// ApplicationStatusHired кандидат принят на работу
+	ApplicationStatusHired = "hired"
+)
+
+// applicationTransitions допустимые переходы между статусами отклика
+var applicationTransitions = map[string][]string{
+	ApplicationStatusNew:       {ApplicationStatusReviewed, ApplicationStatusInterview, ApplicationStatusRejected},
+	ApplicationStatusReviewed:  {ApplicationStatusInterview, ApplicationStatusRejected},
+	ApplicationStatusInterview: {ApplicationStatusHired, ApplicationStatusRejected},
+}
+
+// TableName указывает имя таблицы для модели Application
+func (Application) TableName() string {
+	return "application"
+}
+
+// CanTransitionTo проверяет, допустим ли переход отклика в указанный статус
+func (a *Application) CanTransitionTo(status string) bool {
+	for _, next := range applicationTransitions[a.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal проверяет, завершено ли рассмотрение отклика
+func (a *Application) IsFinal() bool {
+	return a.Status == ApplicationStatusRejected || a.Status == ApplicationStatusHired
+}
+
+// IsValidApplicationStatus проверяет, что статус отклика существует
+func IsValidApplicationStatus(status string) bool {
+	switch status {
+	case ApplicationStatusNew, ApplicationStatusReviewed, ApplicationStatusInterview,
+		ApplicationStatusRejected, ApplicationStatusHired:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Company модель компании-работодателя
+type Company struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"type:varchar(255);not null;index" json:"name"`
+	Description string    `gorm:"type:text" json:"description"`
+	Website     string    `gorm:"type:varchar(255)" json:"website"`
+	Logo        string    `gorm:"type:varchar(512)" json:"logo"`
+	Verified    bool      `gorm:"default:false;not null" json:"verified"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName указывает имя таблицы для модели Company
+func (Company) TableName() string {
+	return "company"
+}
+
+// CompanyMember модель участника компании
+// Связывает пользователей, которые управляют компанией и ее вакансиями
+type CompanyMember struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CompanyID uint      `gorm:"not null;uniqueIndex:idx_company_member" json:"company_id"`
+	Company   *Company  `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	UserID    uint      `gorm:"not null;index;uniqueIndex:idx_company_member" json:"user_id"`
+	User      *User     `gorm:"constraint:OnDelete:CASCADE" json:"user,omitempty"`
+	Role      string    `gorm:"type:varchar(16);default:manager;not null" json:"role"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+const (
+	// CompanyRoleOwner владелец компании, управляет участниками и может удалить компанию
+	CompanyRoleOwner = "owner"
+	// CompanyRoleManager менеджер компании, редактирует компанию и публикует вакансии
+	CompanyRoleManager = "manager"
+)
+
+// TableName указывает имя таблицы для модели CompanyMember
+func (CompanyMember) TableName() string {
+	return "company_member"
+}
+
+// IsOwner проверяет, является ли участник владельцем компании
+func (m *CompanyMember) IsOwner() bool {
+	return m.Role == CompanyRoleOwner
+}
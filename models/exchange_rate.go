@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// BaseCurrency валюта, к которой приводятся курсы и в которой сравниваются зарплаты
+const BaseCurrency = "RUB"
+
+// ExchangeRate курс валюты: стоимость одной единицы валюты в BaseCurrency
+// Таблица заполняется вне приложения, например вручную или внешним загрузчиком курсов.
+// Вакансии можно создавать только в валютах, для которых есть курс
+type ExchangeRate struct {
+	Currency  string    `gorm:"type:varchar(3);primaryKey" json:"currency"`
+	Rate      float64   `gorm:"type:decimal(18,6);not null" json:"rate"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName указывает имя таблицы для модели ExchangeRate
+func (ExchangeRate) TableName() string {
+	return "exchange_rate"
+}
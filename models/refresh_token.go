@@ -0,0 +1,68 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// RefreshToken модель refresh токена
+// В базе хранится только SHA-256 хеш токена. Токены, полученные при ротации
+// одного входа, объединены общим FamilyID, что позволяет отозвать всю цепочку
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	User      *User      `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	TokenHash string     `gorm:"type:char(64);uniqueIndex;not null" json:"-"`
+	FamilyID  string     `gorm:"type:char(32);index;not null" json:"family_id"`
+	UserAgent string     `gorm:"type:varchar(255)" json:"user_agent"`
+	IP        string     `gorm:"type:varchar(45)" json:"ip"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName указывает имя таблицы для модели RefreshToken
+func (RefreshToken) TableName() string {
+	return "refresh_token"
+}
+
+// HashToken возвращает хеш токена для хранения и поиска
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewRefreshToken создает refresh токен пользователя в указанном семействе
+// Возвращает открытое значение токена, которое передается клиенту
+func NewRefreshToken(userID uint, familyID string, ttl time.Duration) (*RefreshToken, string, error) {
+	plain, err := randomHex(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if familyID == "" {
+		if familyID, err = randomHex(16); err != nil {
+			return nil, "", err
+		}
+	}
+
+	token := &RefreshToken{
+		UserID:    userID,
+		TokenHash: HashToken(plain),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	return token, plain, nil
+}
+
+// NewTokenID генерирует уникальный идентификатор токена (claim jti)
+func NewTokenID() (string, error) {
+	return randomHex(16)
+}
+
+// IsExpired проверяет, истек ли срок действия токена
+func (t *RefreshToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
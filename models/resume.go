@@ -0,0 +1,79 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// JSONArray тип для хранения JSON массивов в базе
+type JSONArray []interface{}
+
+// Value преобразует JSONArray в значение для базы данных
+func (a JSONArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan преобразует значение из базы данных в JSONArray
+func (a *JSONArray) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to unmarshal JSONArray value")
+	}
+
+	result := make([]interface{}, 0)
+	err := json.Unmarshal(bytes, &result)
+	*a = result
+	return err
+}
+
+// Resume модель резюме соискателя
+// Опыт работы и образование хранятся как JSON массивы объектов, навыки - как массив строк
+type Resume struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"index;not null" json:"user_id"`
+	User             *User     `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Title            string    `gorm:"type:varchar(255);not null" json:"title"`
+	Summary          string    `gorm:"type:text" json:"summary"`
+	Experience       JSONArray `gorm:"type:json" json:"experience"`
+	Education        JSONArray `gorm:"type:json" json:"education"`
+	Skills           JSONArray `gorm:"type:json" json:"skills"`
+	SkillsText       string    `gorm:"type:text" json:"-"`
+	DesiredSalary    *int      `json:"desired_salary"`
+	AdditionalFields JSONB     `gorm:"type:json" json:"additional_fields,omitempty"`
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName указывает имя таблицы для модели Resume
+func (Resume) TableName() string {
+	return "resume"
+}
+
+// BeforeSave заполняет SkillsText, по которому работает полнотекстовый поиск
+func (r *Resume) BeforeSave(tx *gorm.DB) error {
+	skills := make([]string, 0, len(r.Skills))
+	for _, skill := range r.Skills {
+		skills = append(skills, fmt.Sprint(skill))
+	}
+	r.SkillsText = strings.Join(skills, " ")
+	return nil
+}
+
+// IsOwnedBy проверяет, принадлежит ли резюме пользователю
+func (r *Resume) IsOwnedBy(userID uint) bool {
+	return r.UserID == userID
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Role модель роли пользователя
+// Определяет набор прав: соискатель, работодатель, модератор, администратор
+type Role struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	Name        string    `gorm:"type:varchar(64);uniqueIndex;not null" json:"name"`
+	Description string    `gorm:"type:varchar(255)" json:"description"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+const (
+	// RoleCandidate соискатель, откликается на вакансии
+	RoleCandidate = "candidate"
+	// RoleEmployer работодатель, публикует вакансии
+	RoleEmployer = "employer"
+	// RoleModerator модератор, может изменять и удалять любые вакансии
+	RoleModerator = "moderator"
+	// RoleAdmin администратор, управляет ролями пользователей
+	RoleAdmin = "admin"
+)
+
+// DefaultRoles роли, создаваемые при миграции
+var DefaultRoles = []Role{
+	{Name: RoleCandidate, Description: "Соискатель"},
+	{Name: RoleEmployer, Description: "Работодатель"},
+	{Name: RoleModerator, Description: "Модератор"},
+	{Name: RoleAdmin, Description: "Администратор"},
+}
+
+// SelfAssignableRoles роли, которые пользователь может выбрать при регистрации
+var SelfAssignableRoles = []string{RoleCandidate, RoleEmployer}
+
+// TableName указывает имя таблицы для модели Role
+func (Role) TableName() string {
+	return "role"
+}
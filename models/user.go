@@ -1,6 +1,8 @@
 package models
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -9,15 +11,16 @@ import (
 // User модель пользователя
 // Представляет пользователя системы с безопасной аутентификацией
 type User struct {
-	ID           uint      `gorm:"primaryKey" json:"id"`
-	Username     string    `gorm:"type:varchar(255);uniqueIndex;not null" json:"username" binding:"required"`
-	Email        string    `gorm:"type:varchar(255);uniqueIndex;not null" json:"email" binding:"required,email"`
-	PasswordHash string    `gorm:"type:varchar(255);not null" json:"-"`
-	AuthKey      string    `gorm:"type:varchar(32)" json:"-"`
-	AccessToken  string    `gorm:"type:varchar(64);uniqueIndex" json:"access_token,omitempty"`
-	Status       int       `gorm:"default:10;not null" json:"status"`
-	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	Username        string     `gorm:"type:varchar(255);uniqueIndex;not null" json:"username" binding:"required"`
+	Email           string     `gorm:"type:varchar(255);uniqueIndex;not null" json:"email" binding:"required,email"`
+	PasswordHash    string     `gorm:"type:varchar(255);not null" json:"-"`
+	AuthKey         string     `gorm:"type:varchar(32)" json:"-"`
+	Status          int        `gorm:"default:10;not null" json:"status"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	Roles           []Role     `gorm:"many2many:user_role;constraint:OnDelete:CASCADE" json:"roles,omitempty"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
 const (
@@ -47,3 +50,67 @@ func (u *User) ValidatePassword(password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password))
 	return err == nil
 }
+
+// IsActive проверяет, что учетная запись пользователя не удалена
+func (u *User) IsActive() bool {
+	return u.Status != StatusDeleted
+}
+
+// HasRole проверяет, есть ли у пользователя хотя бы одна из указанных ролей
+func (u *User) HasRole(names ...string) bool {
+	for _, role := range u.Roles {
+		for _, name := range names {
+			if role.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsAdmin проверяет, является ли пользователь администратором
+func (u *User) IsAdmin() bool {
+	return u.HasRole(RoleAdmin)
+}
+
+// CanManageVacancy проверяет, может ли пользователь изменять и удалять вакансию
+// Модераторы и администраторы могут управлять любыми вакансиями
+func (u *User) CanManageVacancy(vacancy *Vacancy) bool {
+	return u.HasRole(RoleAdmin, RoleModerator) || vacancy.IsOwnedBy(u.ID)
+}
+
+// CanViewResume проверяет, может ли пользователь просматривать резюме
+// Резюме видны владельцу, работодателям, модераторам и администраторам
+func (u *User) CanViewResume(resume *Resume) bool {
+	return u.HasRole(RoleEmployer, RoleModerator, RoleAdmin) || resume.IsOwnedBy(u.ID)
+}
+
+// CanManageResume проверяет, может ли пользователь изменять и удалять резюме
+func (u *User) CanManageResume(resume *Resume) bool {
+	return u.HasRole(RoleAdmin, RoleModerator) || resume.IsOwnedBy(u.ID)
+}
+
+// GenerateAuthKey генерирует новый ключ аутентификации
+// Ключ меняется при смене пароля
+func (u *User) GenerateAuthKey() error {
+	key, err := randomHex(16)
+	if err != nil {
+		return err
+	}
+	u.AuthKey = key
+	return nil
+}
+
+// IsEmailVerified проверяет, подтвержден ли email пользователя
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// randomHex возвращает криптографически стойкую случайную строку из n байт в hex
+func randomHex(n int) (string, error) {
+	bytes := make([]byte, n)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
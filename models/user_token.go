@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// UserToken модель одноразового токена пользователя
+// Используется для подтверждения email и восстановления пароля.
+// В базе хранится только SHA-256 хеш токена
+type UserToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"index;not null" json:"user_id"`
+	User      *User      `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Purpose   string     `gorm:"type:varchar(32);index;not null" json:"purpose"`
+	TokenHash string     `gorm:"type:char(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+const (
+	// TokenPurposeEmailVerification токен подтверждения email
+	TokenPurposeEmailVerification = "email_verification"
+	// TokenPurposePasswordReset токен восстановления пароля
+	TokenPurposePasswordReset = "password_reset"
+)
+
+// TableName указывает имя таблицы для модели UserToken
+func (UserToken) TableName() string {
+	return "user_token"
+}
+
+// NewUserToken создает одноразовый токен пользователя
+// Возвращает открытое значение токена, которое отправляется в письме
+func NewUserToken(userID uint, purpose string, ttl time.Duration) (*UserToken, string, error) {
+	plain, err := randomHex(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token := &UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: HashToken(plain),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	return token, plain, nil
+}
+
+// IsValid проверяет, что токен не использован и не просрочен
+func (t *UserToken) IsValid() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
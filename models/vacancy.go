@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // JSONB тип для хранения JSON данных в базе
@@ -39,16 +41,145 @@ func (j *JSONB) Scan(value interface{}) error {
 // Vacancy модель вакансии
 // Представляет сущность вакансии с основными полями и дополнительными данными в формате JSON
 type Vacancy struct {
-	ID               uint      `gorm:"primaryKey" json:"id"`
-	Title            string    `gorm:"type:varchar(255);not null" json:"title" binding:"required,max=255"`
-	Description      string    `gorm:"type:text;not null" json:"description" binding:"required"`
-	Salary           int       `gorm:"not null" json:"salary" binding:"required,min=0"`
-	AdditionalFields JSONB     `gorm:"type:json" json:"additional_fields,omitempty"`
-	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID               uint                `gorm:"primaryKey" json:"id"`
+	Title            string              `gorm:"type:varchar(255);not null" json:"title"`
+	Description      string              `gorm:"type:text;not null" json:"description"`
+	Language         string              `gorm:"type:varchar(8);not null;default:ru" json:"language"`
+	Translations     VacancyTranslations `gorm:"foreignKey:VacancyID;constraint:OnDelete:CASCADE" json:"translations,omitempty"`
+	SalaryFrom       *int                `json:"salary_from"`
+	SalaryTo         *int                `json:"salary_to"`
+	Currency         string              `gorm:"type:varchar(3);not null;default:RUB" json:"currency"`
+	SalaryGross      *bool               `json:"salary_gross"` // true - до вычета налогов, false - на руки, nil - не указано
+	AdditionalFields JSONB               `gorm:"type:json" json:"additional_fields,omitempty"`
+	UserID           *uint               `gorm:"index" json:"user_id"`
+	User             *User               `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+	CompanyID        *uint               `gorm:"index" json:"company_id"`
+	Company          *Company            `gorm:"constraint:OnDelete:SET NULL" json:"company,omitempty"`
+	Status           string              `gorm:"type:varchar(16);default:draft;not null;index" json:"status"`
+	Version          int                 `gorm:"not null;default:1" json:"version"`
+	PublishAt        *time.Time          `gorm:"index" json:"publish_at"`
+	ExpiresAt        *time.Time          `gorm:"index" json:"expires_at"`
+	CreatedAt        time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt        gorm.DeletedAt      `gorm:"index" json:"deleted_at,omitzero"`
+
+	// Relevance релевантность вакансии в результатах поиска, вычисляется запросом и не хранится
+	Relevance float64 `gorm:"column:relevance_score;->;-:migration" json:"-"`
+	// SalaryBase нижняя граница зарплаты в BaseCurrency для сортировки, вычисляется запросом и не хранится
+	SalaryBase *float64 `gorm:"column:salary_base;->;-:migration" json:"-"`
+}
+
+const (
+	// VacancyStatusDraft черновик, виден только владельцу
+	VacancyStatusDraft = "draft"
+	// VacancyStatusPublished опубликованная вакансия, видна всем
+	VacancyStatusPublished = "published"
+	// VacancyStatusPaused публикация приостановлена
+	VacancyStatusPaused = "paused"
+	// VacancyStatusClosed набор закрыт
+	VacancyStatusClosed = "closed"
+	// VacancyStatusArchived вакансия перенесена в архив
+	VacancyStatusArchived = "archived"
+)
+
+// vacancyTransitions допустимые переходы между статусами вакансии
+var vacancyTransitions = map[string][]string{
+	VacancyStatusDraft:     {VacancyStatusPublished, VacancyStatusArchived},
+	VacancyStatusPublished: {VacancyStatusPaused, VacancyStatusClosed},
+	VacancyStatusPaused:    {VacancyStatusPublished, VacancyStatusClosed},
+	VacancyStatusClosed:    {VacancyStatusPublished, VacancyStatusArchived},
 }
 
 // TableName указывает имя таблицы для модели Vacancy
 func (Vacancy) TableName() string {
 	return "vacancy"
 }
+
+// IsOwnedBy проверяет, принадлежит ли вакансия пользователю
+func (v *Vacancy) IsOwnedBy(userID uint) bool {
+	return v.UserID != nil && *v.UserID == userID
+}
+
+// IsPublished проверяет, опубликована ли вакансия
+func (v *Vacancy) IsPublished() bool {
+	return v.Status == VacancyStatusPublished
+}
+
+// IsScheduled проверяет, запланирована ли публикация черновика
+func (v *Vacancy) IsScheduled() bool {
+	return v.Status == VacancyStatusDraft && v.PublishAt != nil
+}
+
+// IsVisibleAt проверяет, видна ли вакансия публично в указанный момент
+// Учитывает publish_at и expires_at, даже если планировщик еще не обновил статус
+func (v *Vacancy) IsVisibleAt(now time.Time) bool {
+	if !v.IsPublished() && !v.IsScheduled() {
+		return false
+	}
+	if v.PublishAt != nil && v.PublishAt.After(now) {
+		return false
+	}
+	return v.ExpiresAt == nil || v.ExpiresAt.After(now)
+}
+
+// CanTransitionTo проверяет, допустим ли переход вакансии в указанный статус
+func (v *Vacancy) CanTransitionTo(status string) bool {
+	for _, next := range vacancyTransitions[v.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Snapshot возвращает снимок редактируемых полей вакансии
+func (v *Vacancy) Snapshot() VacancySnapshot {
+	return VacancySnapshot{
+		Title:            v.Title,
+		Description:      v.Description,
+		Language:         v.Language,
+		Translations:     v.Translations.Map(),
+		SalaryFrom:       v.SalaryFrom,
+		SalaryTo:         v.SalaryTo,
+		Currency:         v.Currency,
+		SalaryGross:      v.SalaryGross,
+		AdditionalFields: v.AdditionalFields,
+		CompanyID:        v.CompanyID,
+		PublishAt:        normalizeTime(v.PublishAt),
+		ExpiresAt:        normalizeTime(v.ExpiresAt),
+		Status:           v.Status,
+	}
+}
+
+// ApplySnapshot переносит в вакансию поля из снимка
+// Статус не восстанавливается: он меняется только допустимыми переходами жизненного цикла
+func (v *Vacancy) ApplySnapshot(snapshot VacancySnapshot) {
+	v.Title = snapshot.Title
+	v.Description = snapshot.Description
+	// Ревизии, созданные до появления переводов, не содержат языка
+	if snapshot.Language != "" {
+		v.Language = snapshot.Language
+	}
+	v.SetTranslations(snapshot.Translations)
+	v.SalaryFrom = snapshot.SalaryFrom
+	v.SalaryTo = snapshot.SalaryTo
+	if snapshot.Currency != "" {
+		v.Currency = snapshot.Currency
+	}
+	v.SalaryGross = snapshot.SalaryGross
+	v.AdditionalFields = snapshot.AdditionalFields
+	v.CompanyID = snapshot.CompanyID
+	v.Company = nil
+	v.PublishAt = snapshot.PublishAt
+	v.ExpiresAt = snapshot.ExpiresAt
+}
+
+// normalizeTime приводит время к UTC с точностью хранения в базе,
+// чтобы снимки одной и той же вакансии совпадали независимо от источника даты
+func normalizeTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	normalized := t.UTC().Truncate(time.Millisecond)
+	return &normalized
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// VacancySnapshot состояние редактируемых полей вакансии на момент ревизии
+// Status пуст в ревизиях, созданных до появления статуса в истории
+type VacancySnapshot struct {
+	Title            string                 `json:"title"`
+	Description      string                 `json:"description"`
+	Language         string                 `json:"language"`
+	Translations     map[string]VacancyText `json:"translations"`
+	SalaryFrom       *int                   `json:"salary_from"`
+	SalaryTo         *int                   `json:"salary_to"`
+	Currency         string                 `json:"currency"`
+	SalaryGross      *bool                  `json:"salary_gross"`
+	AdditionalFields JSONB                  `json:"additional_fields"`
+	CompanyID        *uint                  `json:"company_id"`
+	PublishAt        *time.Time             `json:"publish_at"`
+	ExpiresAt        *time.Time             `json:"expires_at"`
+	Status           string                 `json:"status"`
+}
+
+// Value преобразует снимок в значение для базы данных
+func (s VacancySnapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan преобразует значение из базы данных в снимок
+func (s *VacancySnapshot) Scan(value interface{}) error {
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("failed to unmarshal VacancySnapshot value")
+	}
+	return json.Unmarshal(bytes, s)
+}
+
+// UnmarshalJSON читает снимок, в том числе сохраненный до появления вилки зарплаты:
+// прежнее поле salary переносится в salary_from и salary_to в BaseCurrency
+func (s *VacancySnapshot) UnmarshalJSON(data []byte) error {
+	type snapshot VacancySnapshot
+	var legacy struct {
+		snapshot
+		Salary *int `json:"salary"`
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+
+	*s = VacancySnapshot(legacy.snapshot)
+	if legacy.Salary != nil && s.SalaryFrom == nil && s.SalaryTo == nil {
+		from, to := *legacy.Salary, *legacy.Salary
+		s.SalaryFrom, s.SalaryTo = &from, &to
+		if s.Currency == "" {
+			s.Currency = BaseCurrency
+		}
+	}
+	return nil
+}
+
+// Fields возвращает поля снимка в виде карты с JSON-представлением значений
+func (s VacancySnapshot) Fields() map[string]interface{} {
+	fields := map[string]interface{}{}
+	bytes, _ := json.Marshal(s)
+	_ = json.Unmarshal(bytes, &fields)
+	return fields
+}
+
+// VacancySnapshotFields порядок полей снимка при сравнении ревизий
+var VacancySnapshotFields = []string{
+	"title", "description", "language", "translations",
+	"salary_from", "salary_to", "currency", "salary_gross",
+	"additional_fields", "company_id", "publish_at", "expires_at", "status",
+}
+
+// VacancyRevision ревизия вакансии, сохраняемая при каждом изменении
+type VacancyRevision struct {
+	ID        uint            `gorm:"primaryKey" json:"id"`
+	VacancyID uint            `gorm:"not null;uniqueIndex:idx_vacancy_revision" json:"vacancy_id"`
+	Vacancy   *Vacancy        `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Revision  int             `gorm:"not null;uniqueIndex:idx_vacancy_revision" json:"revision"`
+	UserID    *uint           `gorm:"index" json:"user_id"`
+	User      *User           `gorm:"constraint:OnDelete:SET NULL" json:"-"`
+	Snapshot  VacancySnapshot `gorm:"type:json;not null" json:"snapshot"`
+	CreatedAt time.Time       `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName указывает имя таблицы для модели VacancyRevision
+func (VacancyRevision) TableName() string {
+	return "vacancy_revision"
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+func TestVacancySnapshotLegacySalary(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		wantFrom     *int
+		wantTo       *int
+		wantCurrency string
+	}{
+		{"legacy salary", `{"title":"Go","salary":150000}`, intValue(150000), intValue(150000), BaseCurrency},
+		{"legacy without salary", `{"title":"Go","salary":null}`, nil, nil, ""},
+		{"range", `{"salary_from":1000,"salary_to":3000,"currency":"USD"}`, intValue(1000), intValue(3000), "USD"},
+		{"range wins over legacy salary", `{"salary":5,"salary_from":1000,"currency":"USD"}`, intValue(1000), nil, "USD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var snapshot VacancySnapshot
+			if err := json.Unmarshal([]byte(tt.data), &snapshot); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			got := formatSalary(snapshot.SalaryFrom, snapshot.SalaryTo, snapshot.Currency)
+			if want := formatSalary(tt.wantFrom, tt.wantTo, tt.wantCurrency); got != want {
+				t.Errorf("snapshot salary = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func intValue(value int) *int {
+	return &value
+}
+
+// formatSalary возвращает вилку зарплаты в виде строки "от-до валюта", отсутствующая граница - nil
+func formatSalary(from *int, to *int, currency string) string {
+	bound := func(value *int) string {
+		if value == nil {
+			return "nil"
+		}
+		return strconv.Itoa(*value)
+	}
+	return bound(from) + "-" + bound(to) + " " + currency
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// VacancyText название и описание вакансии на одном языке
+type VacancyText struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// VacancyTranslation перевод названия и описания вакансии
+// Основной текст хранится в самой вакансии на языке Vacancy.Language
+type VacancyTranslation struct {
+	ID          uint      `gorm:"primaryKey" json:"-"`
+	VacancyID   uint      `gorm:"not null;uniqueIndex:idx_vacancy_translation_locale" json:"-"`
+	Locale      string    `gorm:"type:varchar(8);not null;uniqueIndex:idx_vacancy_translation_locale" json:"locale"`
+	Title       string    `gorm:"type:varchar(255);not null" json:"title"`
+	Description string    `gorm:"type:text;not null" json:"description"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"-"`
+}
+
+// TableName указывает имя таблицы для модели VacancyTranslation
+func (VacancyTranslation) TableName() string {
+	return "vacancy_translation"
+}
+
+// VacancyTranslations переводы вакансии, в JSON представлены картой язык -> текст
+type VacancyTranslations []VacancyTranslation
+
+// Map возвращает переводы в виде карты, nil если переводов нет
+func (t VacancyTranslations) Map() map[string]VacancyText {
+	if len(t) == 0 {
+		return nil
+	}
+	result := make(map[string]VacancyText, len(t))
+	for _, translation := range t {
+		result[translation.Locale] = VacancyText{Title: translation.Title, Description: translation.Description}
+	}
+	return result
+}
+
+// MarshalJSON сериализует переводы как карту язык -> текст
+func (t VacancyTranslations) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Map())
+}
+
+// SetTranslations заменяет переводы вакансии, порядок переводов определяется кодом языка
+func (v *Vacancy) SetTranslations(texts map[string]VacancyText) {
+	v.Translations = nil
+	for locale, text := range texts {
+		v.Translations = append(v.Translations, VacancyTranslation{
+			VacancyID:   v.ID,
+			Locale:      locale,
+			Title:       text.Title,
+			Description: text.Description,
+		})
+	}
+	sort.Slice(v.Translations, func(i, j int) bool {
+		return v.Translations[i].Locale < v.Translations[j].Locale
+	})
+}
+
+// Localize подставляет в название и описание вакансии текст на первом подходящем языке из списка
+// Основной текст при этом переносится в переводы, так что ответ остается полным.
+// Если ни один язык не подходит, вакансия не изменяется
+func (v *Vacancy) Localize(languages []string) {
+	for _, lang := range languages {
+		if lang == v.Language {
+			return
+		}
+		for i, translation := range v.Translations {
+			if translation.Locale != lang {
+				continue
+			}
+			v.Translations[i] = VacancyTranslation{
+				VacancyID:   v.ID,
+				Locale:      v.Language,
+				Title:       v.Title,
+				Description: v.Description,
+			}
+			v.Language = translation.Locale
+			v.Title = translation.Title
+			v.Description = translation.Description
+			sort.Slice(v.Translations, func(i, j int) bool {
+				return v.Translations[i].Locale < v.Translations[j].Locale
+			})
+			return
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLocalize(t *testing.T) {
+	tests := []struct {
+		name      string
+		languages []string
+		wantLang  string
+		wantTitle string
+	}{
+		{"base language", []string{"ru"}, "ru", "Разработчик"},
+		{"translation", []string{"en"}, "en", "Developer"},
+		{"first available", []string{"de", "en", "ru"}, "en", "Developer"},
+		{"base before translation", []string{"ru", "en"}, "ru", "Разработчик"},
+		{"fallback to base", []string{"de", "fr"}, "ru", "Разработчик"},
+		{"no preference", nil, "ru", "Разработчик"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vacancy := &Vacancy{ID: 1, Language: "ru", Title: "Разработчик", Description: "Описание"}
+			vacancy.SetTranslations(map[string]VacancyText{
+				"en": {Title: "Developer", Description: "Description"},
+				"uk": {Title: "Розробник", Description: "Опис"},
+			})
+			texts := vacancy.Translations.Map()
+			texts[vacancy.Language] = VacancyText{Title: vacancy.Title, Description: vacancy.Description}
+
+			vacancy.Localize(tt.languages)
+
+			if vacancy.Language != tt.wantLang || vacancy.Title != tt.wantTitle {
+				t.Errorf("Localize() = %s %q, want %s %q", vacancy.Language, vacancy.Title, tt.wantLang, tt.wantTitle)
+			}
+			// Все тексты сохраняются: основной переносится в переводы
+			got := vacancy.Translations.Map()
+			got[vacancy.Language] = VacancyText{Title: vacancy.Title, Description: vacancy.Description}
+			if !reflect.DeepEqual(got, texts) {
+				t.Errorf("texts after Localize() = %v, want %v", got, texts)
+			}
+		})
+	}
+}
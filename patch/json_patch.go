@@ -0,0 +1,318 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Operation операция JSON Patch (RFC 6902)
+type Operation struct {
+	Op    string       `json:"op"`
+	Path  *string      `json:"path"`
+	From  *string      `json:"from"`
+	Value *interface{} `json:"value"`
+}
+
+// UnmarshalJSON различает отсутствующее поле value и value: null
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*o = Operation{}
+	for key, target := range map[string]interface{}{"op": &o.Op, "path": &o.Path, "from": &o.From} {
+		if value, ok := raw[key]; ok {
+			if err := json.Unmarshal(value, target); err != nil {
+				return err
+			}
+		}
+	}
+
+	if value, ok := raw["value"]; ok {
+		var decoded interface{}
+		if err := json.Unmarshal(value, &decoded); err != nil {
+			return err
+		}
+		o.Value = &decoded
+	}
+	return nil
+}
+
+// Apply применяет операции JSON Patch к документу
+// Операции выполняются по порядку; при первой ошибке патч отклоняется целиком,
+// исходный документ при этом не изменяется
+func Apply(document interface{}, operations []Operation) (interface{}, error) {
+	doc := deepCopy(document)
+
+	for i, operation := range operations {
+		var err error
+		doc, err = applyOperation(doc, operation)
+		if err != nil {
+			path := ""
+			if operation.Path != nil {
+				path = *operation.Path
+			}
+			patchErr := &Error{Index: i, Op: operation.Op, Path: path, Message: err.Error()}
+			var reason *operationError
+			if errors.As(err, &reason) {
+				patchErr.Reason = reason.reason
+				patchErr.Value = reason.value
+			}
+			return nil, patchErr
+		}
+	}
+
+	return doc, nil
+}
+
+// applyOperation выполняет одну операцию
+func applyOperation(doc interface{}, operation Operation) (interface{}, error) {
+	if operation.Path == nil {
+		return nil, errorf("missing_path", "отсутствует поле path")
+	}
+	path, err := parsePointer(*operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add":
+		if operation.Value == nil {
+			return nil, errorf("missing_value", "отсутствует поле value")
+		}
+		return add(doc, path, deepCopy(*operation.Value))
+	case "remove":
+		doc, _, err := remove(doc, path)
+		return doc, err
+	case "replace":
+		if operation.Value == nil {
+			return nil, errorf("missing_value", "отсутствует поле value")
+		}
+		if _, err := get(doc, path); err != nil {
+			return nil, err
+		}
+		if doc, _, err = remove(doc, path); err != nil {
+			return nil, err
+		}
+		return add(doc, path, deepCopy(*operation.Value))
+	case "move", "copy":
+		if operation.From == nil {
+			return nil, errorf("missing_from", "отсутствует поле from")
+		}
+		from, err := parsePointer(*operation.From)
+		if err != nil {
+			return nil, err
+		}
+		if operation.Op == "move" && isPrefix(from, path) && len(from) < len(path) {
+			return nil, errorf("move_into_self", "нельзя переместить значение внутрь самого себя")
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if operation.Op == "move" {
+			if doc, _, err = remove(doc, from); err != nil {
+				return nil, err
+			}
+		} else {
+			value = deepCopy(value)
+		}
+		return add(doc, path, value)
+	case "test":
+		if operation.Value == nil {
+			return nil, errorf("missing_value", "отсутствует поле value")
+		}
+		value, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(value, *operation.Value) {
+			return nil, errorf("test_failed", "значение не совпадает с ожидаемым")
+		}
+		return doc, nil
+	default:
+		return nil, errorf("unknown_op", "неизвестная операция %q", operation.Op)
+	}
+}
+
+// parsePointer разбирает JSON Pointer (RFC 6901)
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errorf("invalid_pointer", "путь %q должен начинаться с /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// get возвращает значение по пути
+func get(doc interface{}, path []string) (interface{}, error) {
+	current := doc
+	for _, token := range path {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, errorf("key_not_found", "ключ %q не найден", token)
+			}
+			current = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			current = node[index]
+		default:
+			return nil, errorf("not_container", "значение по пути не является объектом или массивом")
+		}
+	}
+	return current, nil
+}
+
+// add вставляет значение по пути и возвращает измененный документ
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[token] = value
+		return doc, nil
+	case []interface{}:
+		index := len(node)
+		if token != "-" {
+			if index, err = arrayIndex(token, len(node)); err != nil {
+				return nil, err
+			}
+		}
+		updated := append(node[:index:index], append([]interface{}{value}, node[index:]...)...)
+		return replaceAt(doc, path[:len(path)-1], updated)
+	default:
+		return nil, errorf("not_container", "значение по пути не является объектом или массивом")
+	}
+}
+
+// remove удаляет значение по пути и возвращает измененный документ и удаленное значение
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	token := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		value, ok := node[token]
+		if !ok {
+			return nil, nil, errorf("key_not_found", "ключ %q не найден", token)
+		}
+		delete(node, token)
+		return doc, value, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := node[index]
+		updated := append(node[:index:index], node[index+1:]...)
+		doc, err = replaceAt(doc, path[:len(path)-1], updated)
+		return doc, value, err
+	default:
+		return nil, nil, errorf("not_container", "значение по пути не является объектом или массивом")
+	}
+}
+
+// replaceAt заменяет значение по существующему пути, возвращая измененный документ
+// Нужен для массивов: изменение длины среза не видно через ссылку у родителя
+func replaceAt(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[token] = value
+	case []interface{}:
+		index, err := arrayIndex(token, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = value
+	}
+	return doc, nil
+}
+
+// arrayIndex разбирает индекс массива, не превышающий max
+func arrayIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, errorf("invalid_index", "неверный индекс массива %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, errorf("invalid_index", "неверный индекс массива %q", token)
+	}
+	if index > max {
+		return 0, errorf("index_out_of_range", "индекс массива %d вне границ", index)
+	}
+	return index, nil
+}
+
+// isPrefix проверяет, что путь prefix является началом пути path
+func isPrefix(prefix []string, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// deepCopy копирует JSON-значение, чтобы операции не меняли исходный документ
+func deepCopy(value interface{}) interface{} {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(node))
+		for key, item := range node {
+			result[key] = deepCopy(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(node))
+		for i, item := range node {
+			result[i] = deepCopy(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
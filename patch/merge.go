@@ -0,0 +1,28 @@
+package patch
+
+// MergePatch применяет JSON Merge Patch (RFC 7386) к документу
+// null в патче удаляет ключ, вложенные объекты объединяются рекурсивно,
+// любое другое значение заменяет прежнее целиком. Исходный документ не изменяется
+func MergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	result := map[string]interface{}{}
+	if targetObject, ok := target.(map[string]interface{}); ok {
+		for key, value := range targetObject {
+			result[key] = value
+		}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = MergePatch(result[key], value)
+	}
+
+	return result
+}
@@ -0,0 +1,45 @@
+package patch
+
+import "fmt"
+
+// Error ошибка применения патча с указанием операции и пути
+// Index равен -1 для ошибок JSON Merge Patch, где операций нет
+// Reason содержит стабильный код причины, Value - значение, к которому она относится
+type Error struct {
+	Index   int    `json:"index"`
+	Op      string `json:"op,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Value   string `json:"-"`
+	Message string `json:"message"`
+}
+
+// Error реализует интерфейс error
+func (e *Error) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("%s: %s", e.Path, e.Message)
+	}
+	return fmt.Sprintf("операция %d (%s %s): %s", e.Index, e.Op, e.Path, e.Message)
+}
+
+// operationError причина ошибки отдельной операции
+type operationError struct {
+	reason  string
+	value   string
+	message string
+}
+
+// Error реализует интерфейс error
+func (e *operationError) Error() string {
+	return e.message
+}
+
+// errorf формирует причину ошибки операции с кодом reason
+// Первый аргумент форматирования запоминается как значение, к которому относится ошибка
+func errorf(reason string, format string, args ...interface{}) error {
+	err := &operationError{reason: reason, message: fmt.Sprintf(format, args...)}
+	if len(args) > 0 {
+		err.value = fmt.Sprint(args[0])
+	}
+	return err
+}
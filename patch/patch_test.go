@@ -0,0 +1,323 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// decode разбирает JSON-значение для теста
+func decode(t *testing.T, data string) interface{} {
+	t.Helper()
+	var value interface{}
+	if err := json.Unmarshal([]byte(data), &value); err != nil {
+		t.Fatalf("invalid JSON %s: %v", data, err)
+	}
+	return value
+}
+
+// decodeOperations разбирает список операций JSON Patch для теста
+func decodeOperations(t *testing.T, data string) []Operation {
+	t.Helper()
+	var operations []Operation
+	if err := json.Unmarshal([]byte(data), &operations); err != nil {
+		t.Fatalf("invalid patch %s: %v", data, err)
+	}
+	return operations
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name       string
+		document   string
+		patch      string
+		want       string
+		wantReason string // код причины ошибки, пустой - патч применяется
+	}{
+		{
+			name:     "add object member",
+			document: `{"a":1}`,
+			patch:    `[{"op":"add","path":"/b","value":2}]`,
+			want:     `{"a":1,"b":2}`,
+		},
+		{
+			name:     "add replaces existing member",
+			document: `{"a":1}`,
+			patch:    `[{"op":"add","path":"/a","value":[1,2]}]`,
+			want:     `{"a":[1,2]}`,
+		},
+		{
+			name:     "add null value",
+			document: `{}`,
+			patch:    `[{"op":"add","path":"/a","value":null}]`,
+			want:     `{"a":null}`,
+		},
+		{
+			name:     "add inserts into array",
+			document: `{"a":["x","z"]}`,
+			patch:    `[{"op":"add","path":"/a/1","value":"y"}]`,
+			want:     `{"a":["x","y","z"]}`,
+		},
+		{
+			name:     "add appends with dash",
+			document: `{"a":["x"]}`,
+			patch:    `[{"op":"add","path":"/a/-","value":"y"},{"op":"add","path":"/a/-","value":"z"}]`,
+			want:     `{"a":["x","y","z"]}`,
+		},
+		{
+			name:     "add at array length",
+			document: `{"a":["x"]}`,
+			patch:    `[{"op":"add","path":"/a/1","value":"y"}]`,
+			want:     `{"a":["x","y"]}`,
+		},
+		{
+			name:     "add replaces whole document",
+			document: `{"a":1}`,
+			patch:    `[{"op":"add","path":"","value":["b"]}]`,
+			want:     `["b"]`,
+		},
+		{
+			name:       "add beyond array length",
+			document:   `{"a":["x"]}`,
+			patch:      `[{"op":"add","path":"/a/2","value":"y"}]`,
+			wantReason: "index_out_of_range",
+		},
+		{
+			name:       "add with missing parent",
+			document:   `{}`,
+			patch:      `[{"op":"add","path":"/a/b","value":1}]`,
+			wantReason: "key_not_found",
+		},
+		{
+			name:       "add without value",
+			document:   `{}`,
+			patch:      `[{"op":"add","path":"/a"}]`,
+			wantReason: "missing_value",
+		},
+		{
+			name:     "remove object member",
+			document: `{"a":1,"b":2}`,
+			patch:    `[{"op":"remove","path":"/a"}]`,
+			want:     `{"b":2}`,
+		},
+		{
+			name:     "remove array element",
+			document: `{"a":["x","y","z"]}`,
+			patch:    `[{"op":"remove","path":"/a/1"}]`,
+			want:     `{"a":["x","z"]}`,
+		},
+		{
+			name:       "remove missing member",
+			document:   `{"a":1}`,
+			patch:      `[{"op":"remove","path":"/b"}]`,
+			wantReason: "key_not_found",
+		},
+		{
+			name:       "remove with dash",
+			document:   `{"a":["x"]}`,
+			patch:      `[{"op":"remove","path":"/a/-"}]`,
+			wantReason: "invalid_index",
+		},
+		{
+			name:     "replace object member",
+			document: `{"a":1,"b":2}`,
+			patch:    `[{"op":"replace","path":"/a","value":{"c":3}}]`,
+			want:     `{"a":{"c":3},"b":2}`,
+		},
+		{
+			name:     "replace array element",
+			document: `{"a":["x","y"]}`,
+			patch:    `[{"op":"replace","path":"/a/0","value":"z"}]`,
+			want:     `{"a":["z","y"]}`,
+		},
+		{
+			name:       "replace missing member",
+			document:   `{"a":1}`,
+			patch:      `[{"op":"replace","path":"/b","value":2}]`,
+			wantReason: "key_not_found",
+		},
+		{
+			name:     "move object member",
+			document: `{"a":{"b":1},"c":{}}`,
+			patch:    `[{"op":"move","from":"/a/b","path":"/c/d"}]`,
+			want:     `{"a":{},"c":{"d":1}}`,
+		},
+		{
+			name:     "move array element",
+			document: `{"a":["x","y","z"]}`,
+			patch:    `[{"op":"move","from":"/a/0","path":"/a/2"}]`,
+			want:     `{"a":["y","z","x"]}`,
+		},
+		{
+			name:       "move into own child",
+			document:   `{"a":{"b":{}}}`,
+			patch:      `[{"op":"move","from":"/a","path":"/a/b/c"}]`,
+			wantReason: "move_into_self",
+		},
+		{
+			name:       "move without from",
+			document:   `{"a":1}`,
+			patch:      `[{"op":"move","path":"/b"}]`,
+			wantReason: "missing_from",
+		},
+		{
+			name:     "copy object member",
+			document: `{"a":{"b":[1]}}`,
+			patch:    `[{"op":"copy","from":"/a","path":"/c"},{"op":"add","path":"/c/b/-","value":2}]`,
+			want:     `{"a":{"b":[1]},"c":{"b":[1,2]}}`,
+		},
+		{
+			name:     "copy array element to end",
+			document: `{"a":["x","y"]}`,
+			patch:    `[{"op":"copy","from":"/a/0","path":"/a/-"}]`,
+			want:     `{"a":["x","y","x"]}`,
+		},
+		{
+			name:     "test passes",
+			document: `{"a":{"b":[1,"c",null]}}`,
+			patch:    `[{"op":"test","path":"/a","value":{"b":[1,"c",null]}}]`,
+			want:     `{"a":{"b":[1,"c",null]}}`,
+		},
+		{
+			name:       "test fails on different value",
+			document:   `{"a":1}`,
+			patch:      `[{"op":"test","path":"/a","value":"1"}]`,
+			wantReason: "test_failed",
+		},
+		{
+			name:     "escaped slash and tilde",
+			document: `{"a/b":1,"m~n":2}`,
+			patch:    `[{"op":"replace","path":"/a~1b","value":3},{"op":"remove","path":"/m~0n"},{"op":"add","path":"/~01","value":4}]`,
+			want:     `{"a/b":3,"~1":4}`,
+		},
+		{
+			name:     "empty key",
+			document: `{"":1}`,
+			patch:    `[{"op":"test","path":"/","value":1}]`,
+			want:     `{"":1}`,
+		},
+		{
+			name:       "pointer without leading slash",
+			document:   `{"a":1}`,
+			patch:      `[{"op":"remove","path":"a"}]`,
+			wantReason: "invalid_pointer",
+		},
+		{
+			name:       "index with leading zero",
+			document:   `{"a":["x","y"]}`,
+			patch:      `[{"op":"remove","path":"/a/01"}]`,
+			wantReason: "invalid_index",
+		},
+		{
+			name:       "unknown operation",
+			document:   `{}`,
+			patch:      `[{"op":"merge","path":"/a","value":1}]`,
+			wantReason: "unknown_op",
+		},
+		{
+			name:       "missing path",
+			document:   `{}`,
+			patch:      `[{"op":"add","value":1}]`,
+			wantReason: "missing_path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply(decode(t, tt.document), decodeOperations(t, tt.patch))
+			if tt.wantReason != "" {
+				var patchErr *Error
+				if !errors.As(err, &patchErr) {
+					t.Fatalf("Apply() error = %v, want reason %q", err, tt.wantReason)
+				}
+				if patchErr.Reason != tt.wantReason {
+					t.Errorf("Apply() reason = %q, want %q", patchErr.Reason, tt.wantReason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if want := decode(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("Apply() = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyFailedTestAbortsPatch(t *testing.T) {
+	document := decode(t, `{"a":1,"b":["x"]}`)
+	operations := decodeOperations(t, `[
+		{"op":"replace","path":"/a","value":2},
+		{"op":"add","path":"/b/-","value":"y"},
+		{"op":"test","path":"/a","value":1},
+		{"op":"remove","path":"/b"}
+	]`)
+
+	got, err := Apply(document, operations)
+	if got != nil {
+		t.Errorf("Apply() = %#v, want nil", got)
+	}
+
+	var patchErr *Error
+	if !errors.As(err, &patchErr) {
+		t.Fatalf("Apply() error = %v, want *Error", err)
+	}
+	if patchErr.Index != 2 || patchErr.Op != "test" || patchErr.Path != "/a" || patchErr.Reason != "test_failed" {
+		t.Errorf("Apply() error = %+v, want operation 2 test /a test_failed", patchErr)
+	}
+
+	// Операции до ошибки не должны затронуть исходный документ
+	if want := decode(t, `{"a":1,"b":["x"]}`); !reflect.DeepEqual(document, want) {
+		t.Errorf("document modified to %#v", document)
+	}
+}
+
+func TestOperationDistinguishesNullValue(t *testing.T) {
+	operations := decodeOperations(t, `[{"op":"add","path":"/a","value":null},{"op":"add","path":"/a"}]`)
+	if operations[0].Value == nil || *operations[0].Value != nil {
+		t.Errorf("value: null decoded as %#v, want pointer to nil", operations[0].Value)
+	}
+	if operations[1].Value != nil {
+		t.Errorf("missing value decoded as %#v, want nil", operations[1].Value)
+	}
+}
+
+// TestMergePatch примеры из RFC 7386, Appendix A
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		target string
+		patch  string
+		want   string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target+" + "+tt.patch, func(t *testing.T) {
+			target := decode(t, tt.target)
+			got := MergePatch(target, decode(t, tt.patch))
+			if want := decode(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("MergePatch() = %#v, want %#v", got, want)
+			}
+			if original := decode(t, tt.target); !reflect.DeepEqual(target, original) {
+				t.Errorf("target modified to %#v", target)
+			}
+		})
+	}
+}
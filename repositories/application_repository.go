@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"vakansii-back-go/models"
+
+	"gorm.io/gorm"
+)
+
+// ApplicationRepository интерфейс для работы с откликами на вакансии
+type ApplicationRepository interface {
+	FindByID(id uint) (*models.Application, error)
+	FindByVacancyAndUser(vacancyID uint, userID uint) (*models.Application, error)
+	FindByVacancy(vacancyID uint, status string, page int) ([]models.Application, int64, error)
+	FindByUser(userID uint, page int) ([]models.Application, int64, error)
+	Save(application *models.Application) error
+	Update(application *models.Application) error
+	Delete(id uint) error
+}
+
+// applicationRepository реализация ApplicationRepository
+type applicationRepository struct {
+	db *gorm.DB
+}
+
+// NewApplicationRepository создает новый экземпляр репозитория откликов
+func NewApplicationRepository(db *gorm.DB) ApplicationRepository {
+	return &applicationRepository{db: db}
+}
+
+// FindByID находит отклик по ID вместе с вакансией
+func (r *applicationRepository) FindByID(id uint) (*models.Application, error) {
+	var application models.Application
+	if err := r.db.Preload("Vacancy").First(&application, id).Error; err != nil {
+		return nil, err
+	}
+	return &application, nil
+}
+
+// FindByVacancyAndUser находит отклик пользователя на вакансию
+func (r *applicationRepository) FindByVacancyAndUser(vacancyID uint, userID uint) (*models.Application, error) {
+	var application models.Application
+	if err := r.db.Where("vacancy_id = ? AND user_id = ?", vacancyID, userID).First(&application).Error; err != nil {
+		return nil, err
+	}
+	return &application, nil
+}
+
+// FindByVacancy получает отклики на вакансию вместе с кандидатами
+// Пустой status означает отклики в любом статусе
+func (r *applicationRepository) FindByVacancy(vacancyID uint, status string, page int) ([]models.Application, int64, error) {
+	var applications []models.Application
+	var total int64
+
+	db := r.db.Model(&models.Application{}).Where("vacancy_id = ?", vacancyID)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * PageSize
+
+	if err := db.Preload("User").Order("created_at ASC").Limit(PageSize).Offset(offset).Find(&applications).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return applications, total, nil
+}
+
+// FindByUser получает отклики пользователя вместе с вакансиями
+func (r *applicationRepository) FindByUser(userID uint, page int) ([]models.Application, int64, error) {
+	var applications []models.Application
+	var total int64
+
+	db := r.db.Model(&models.Application{}).Where("user_id = ?", userID)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * PageSize
+
+	if err := db.Preload("Vacancy").Order("created_at DESC").Limit(PageSize).Offset(offset).Find(&applications).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return applications, total, nil
+}
+
+// Save сохраняет новый отклик
+func (r *applicationRepository) Save(application *models.Application) error {
+	return r.db.Create(application).Error
+}
+
+// Update обновляет существующий отклик
+func (r *applicationRepository) Update(application *models.Application) error {
+	return r.db.Omit("Vacancy", "User").Save(application).Error
+}
+
+// Delete удаляет отклик по ID
+func (r *applicationRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Application{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
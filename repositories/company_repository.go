@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"vakansii-back-go/models"
+
+	"gorm.io/gorm"
+)
+
+// CompanyRepository интерфейс для работы с компаниями и их участниками
+type CompanyRepository interface {
+	FindByID(id uint) (*models.Company, error)
+	FindAll(page int) ([]models.Company, int64, error)
+	Save(company *models.Company, ownerID uint) error
+	Update(company *models.Company) error
+	Delete(id uint) error
+	FindMember(companyID uint, userID uint) (*models.CompanyMember, error)
+	FindMembers(companyID uint) ([]models.CompanyMember, error)
+	SaveMember(member *models.CompanyMember) error
+	DeleteMember(companyID uint, userID uint) error
+}
+
+// companyRepository реализация CompanyRepository
+type companyRepository struct {
+	db *gorm.DB
+}
+
+// NewCompanyRepository создает новый экземпляр репозитория компаний
+func NewCompanyRepository(db *gorm.DB) CompanyRepository {
+	return &companyRepository{db: db}
+}
+
+// FindByID находит компанию по ID
+func (r *companyRepository) FindByID(id uint) (*models.Company, error) {
+	var company models.Company
+	if err := r.db.First(&company, id).Error; err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
+
+// FindAll получает все компании с пагинацией
+func (r *companyRepository) FindAll(page int) ([]models.Company, int64, error) {
+	var companies []models.Company
+	var total int64
+
+	if err := r.db.Model(&models.Company{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * PageSize
+
+	if err := r.db.Order("name ASC").Limit(PageSize).Offset(offset).Find(&companies).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return companies, total, nil
+}
+
+// Save сохраняет новую компанию и делает пользователя ее владельцем
+func (r *companyRepository) Save(company *models.Company, ownerID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(company).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.CompanyMember{
+			CompanyID: company.ID,
+			UserID:    ownerID,
+			Role:      models.CompanyRoleOwner,
+		}).Error
+	})
+}
+
+// Update обновляет существующую компанию
+func (r *companyRepository) Update(company *models.Company) error {
+	return r.db.Save(company).Error
+}
+
+// Delete удаляет компанию по ID
+func (r *companyRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Company{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindMember находит участника компании
+func (r *companyRepository) FindMember(companyID uint, userID uint) (*models.CompanyMember, error) {
+	var member models.CompanyMember
+	if err := r.db.Where("company_id = ? AND user_id = ?", companyID, userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// FindMembers получает всех участников компании
+func (r *companyRepository) FindMembers(companyID uint) ([]models.CompanyMember, error) {
+	var members []models.CompanyMember
+	if err := r.db.Preload("User").Where("company_id = ?", companyID).Order("created_at ASC").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// SaveMember добавляет участника компании или обновляет его роль
+func (r *companyRepository) SaveMember(member *models.CompanyMember) error {
+	return r.db.Omit("Company", "User").Save(member).Error
+}
+
+// DeleteMember удаляет участника компании
+func (r *companyRepository) DeleteMember(companyID uint, userID uint) error {
+	result := r.db.Where("company_id = ? AND user_id = ?", companyID, userID).Delete(&models.CompanyMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
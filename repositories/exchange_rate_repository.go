@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"vakansii-back-go/models"
+
+	"gorm.io/gorm"
+)
+
+// ExchangeRateRepository интерфейс для работы с курсами валют
+type ExchangeRateRepository interface {
+	FindByCurrency(currency string) (*models.ExchangeRate, error)
+}
+
+// exchangeRateRepository реализация ExchangeRateRepository
+type exchangeRateRepository struct {
+	db *gorm.DB
+}
+
+// NewExchangeRateRepository создает новый экземпляр репозитория курсов валют
+func NewExchangeRateRepository(db *gorm.DB) ExchangeRateRepository {
+	return &exchangeRateRepository{db: db}
+}
+
+// FindByCurrency находит курс валюты по ее коду
+func (r *exchangeRateRepository) FindByCurrency(currency string) (*models.ExchangeRate, error) {
+	var rate models.ExchangeRate
+	if err := r.db.Where("currency = ?", currency).First(&rate).Error; err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"time"
+	"vakansii-back-go/models"
+
+	"gorm.io/gorm"
+)
+
+// RefreshTokenRepository интерфейс для работы с refresh токенами
+type RefreshTokenRepository interface {
+	FindByHash(hash string) (*models.RefreshToken, error)
+	Save(token *models.RefreshToken) error
+	MarkUsed(id uint) (bool, error)
+	RevokeFamily(familyID string) error
+	RevokeAllForUser(userID uint) error
+}
+
+// refreshTokenRepository реализация RefreshTokenRepository
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository создает новый экземпляр репозитория refresh токенов
+func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
+	return &refreshTokenRepository{db: db}
+}
+
+// FindByHash находит refresh токен по хешу
+func (r *refreshTokenRepository) FindByHash(hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.db.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Save сохраняет новый refresh токен
+func (r *refreshTokenRepository) Save(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// MarkUsed помечает токен использованным
+// Возвращает false, если токен уже был использован или отозван (в том числе параллельным запросом)
+func (r *refreshTokenRepository) MarkUsed(id uint) (bool, error) {
+	result := r.db.Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// RevokeFamily отзывает все токены семейства
+func (r *refreshTokenRepository) RevokeFamily(familyID string) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAllForUser отзывает все токены пользователя на всех устройствах
+func (r *refreshTokenRepository) RevokeAllForUser(userID uint) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"fmt"
+	"strings"
+	"vakansii-back-go/models"
+
+	"gorm.io/gorm"
+)
+
+// ResumeRepository интерфейс для работы с резюме
+type ResumeRepository interface {
+	FindByID(id uint) (*models.Resume, error)
+	FindAll(page int, sortBy string, sortOrder string) ([]models.Resume, int64, error)
+	FindByUser(userID uint, page int) ([]models.Resume, int64, error)
+	Save(resume *models.Resume) error
+	Update(resume *models.Resume) error
+	Delete(id uint) error
+	Search(query string, page int, sortOrder string) ([]models.Resume, int64, error)
+}
+
+// resumeRepository реализация ResumeRepository
+type resumeRepository struct {
+	db *gorm.DB
+}
+
+// NewResumeRepository создает новый экземпляр репозитория резюме
+func NewResumeRepository(db *gorm.DB) ResumeRepository {
+	return &resumeRepository{db: db}
+}
+
+// FindByID находит резюме по ID
+func (r *resumeRepository) FindByID(id uint) (*models.Resume, error) {
+	var resume models.Resume
+	if err := r.db.First(&resume, id).Error; err != nil {
+		return nil, err
+	}
+	return &resume, nil
+}
+
+// FindAll получает все резюме с пагинацией и сортировкой
+func (r *resumeRepository) FindAll(page int, sortBy string, sortOrder string) ([]models.Resume, int64, error) {
+	var resumes []models.Resume
+	var total int64
+
+	if err := r.db.Model(&models.Resume{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Валидация полей сортировки
+	allowedSortFields := map[string]bool{
+		"desired_salary": true,
+		"created_at":     true,
+		"updated_at":     true,
+	}
+	if !allowedSortFields[sortBy] {
+		sortBy = "updated_at"
+	}
+
+	// Валидация порядка сортировки
+	if sortOrder != "asc" && sortOrder != "desc" {
+		sortOrder = "desc"
+	}
+
+	offset := (page - 1) * PageSize
+	orderClause := fmt.Sprintf("%s %s", sortBy, sortOrder)
+
+	if err := r.db.Order(orderClause).Limit(PageSize).Offset(offset).Find(&resumes).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return resumes, total, nil
+}
+
+// FindByUser получает резюме пользователя
+func (r *resumeRepository) FindByUser(userID uint, page int) ([]models.Resume, int64, error) {
+	var resumes []models.Resume
+	var total int64
+
+	db := r.db.Model(&models.Resume{}).Where("user_id = ?", userID)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * PageSize
+
+	if err := db.Order("updated_at DESC").Limit(PageSize).Offset(offset).Find(&resumes).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return resumes, total, nil
+}
+
+// Save сохраняет новое резюме
+func (r *resumeRepository) Save(resume *models.Resume) error {
+	return r.db.Create(resume).Error
+}
+
+// Update обновляет существующее резюме
+func (r *resumeRepository) Update(resume *models.Resume) error {
+	return r.db.Save(resume).Error
+}
+
+// Delete удаляет резюме по ID
+func (r *resumeRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Resume{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Search выполняет полнотекстовый поиск по резюме (заголовок, описание, навыки)
+func (r *resumeRepository) Search(query string, page int, sortOrder string) ([]models.Resume, int64, error) {
+	var resumes []models.Resume
+	var total int64
+
+	query = strings.TrimSpace(query)
+
+	db := r.db.Model(&models.Resume{}).
+		Where("MATCH(title, summary, skills_text) AGAINST (? IN NATURAL LANGUAGE MODE)", query)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * PageSize
+
+	if sortOrder == "relevance" {
+		db = db.Select("*, MATCH(title, summary, skills_text) AGAINST (? IN NATURAL LANGUAGE MODE) as relevance_score", query).
+			Order("relevance_score DESC")
+	} else if sortOrder == "asc" {
+		db = db.Order("updated_at ASC")
+	} else {
+		db = db.Order("updated_at DESC")
+	}
+
+	if err := db.Limit(PageSize).Offset(offset).Find(&resumes).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return resumes, total, nil
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"vakansii-back-go/models"
+
+	"gorm.io/gorm"
+)
+
+// RoleRepository интерфейс для работы с ролями
+type RoleRepository interface {
+	FindByName(name string) (*models.Role, error)
+	FindAll() ([]models.Role, error)
+}
+
+// roleRepository реализация RoleRepository
+type roleRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository создает новый экземпляр репозитория ролей
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+// FindByName находит роль по имени
+func (r *roleRepository) FindByName(name string) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.Where("name = ?", name).First(&role).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// FindAll получает все роли
+func (r *roleRepository) FindAll() ([]models.Role, error) {
+	var roles []models.Role
+	if err := r.db.Order("id ASC").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
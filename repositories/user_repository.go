@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"vakansii-back-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserRepository интерфейс для работы с пользователями
+type UserRepository interface {
+	FindByID(id uint) (*models.User, error)
+	FindByUsername(username string) (*models.User, error)
+	FindByEmail(email string) (*models.User, error)
+	Save(user *models.User) error
+	Update(user *models.User) error
+	AddRole(user *models.User, role *models.Role) error
+	RemoveRole(user *models.User, role *models.Role) error
+	RemoveRoleIfNotLast(user *models.User, role *models.Role) (bool, error)
+}
+
+// userRepository реализация UserRepository
+type userRepository struct {
+	db *gorm.DB
+}
+
+// NewUserRepository создает новый экземпляр репозитория пользователей
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+// FindByID находит пользователя по ID
+func (r *userRepository) FindByID(id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.Preload("Roles").First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByUsername находит пользователя по имени
+func (r *userRepository) FindByUsername(username string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Preload("Roles").Where("username = ?", username).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByEmail находит пользователя по email
+func (r *userRepository) FindByEmail(email string) (*models.User, error) {
+	var user models.User
+	if err := r.db.Preload("Roles").Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Save сохраняет нового пользователя
+func (r *userRepository) Save(user *models.User) error {
+	return r.db.Create(user).Error
+}
+
+// Update обновляет существующего пользователя
+// Связи с ролями не изменяются, для этого используются AddRole и RemoveRole
+func (r *userRepository) Update(user *models.User) error {
+	return r.db.Omit("Roles").Save(user).Error
+}
+
+// AddRole назначает пользователю роль
+func (r *userRepository) AddRole(user *models.User, role *models.Role) error {
+	return r.db.Model(user).Association("Roles").Append(role)
+}
+
+// RemoveRole отзывает у пользователя роль
+func (r *userRepository) RemoveRole(user *models.User, role *models.Role) error {
+	return r.db.Model(user).Association("Roles").Delete(role)
+}
+
+// RemoveRoleIfNotLast отзывает у пользователя роль, если у нее останутся другие владельцы
+// Возвращает false, если роль не отозвана. Строки user_role роли блокируются до конца транзакции,
+// чтобы параллельные запросы не отозвали роль у всех владельцев сразу
+func (r *userRepository) RemoveRoleIfNotLast(user *models.User, role *models.Role) (bool, error) {
+	removed := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var holders []uint
+		err := tx.Table("user_role").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("role_id = ?", role.ID).
+			Pluck("user_id", &holders).Error
+		if err != nil {
+			return err
+		}
+		if len(holders) <= 1 {
+			return nil
+		}
+
+		if err := tx.Model(user).Association("Roles").Delete(role); err != nil {
+			return err
+		}
+		removed = true
+		return nil
+	})
+	return removed, err
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"time"
+	"vakansii-back-go/models"
+
+	"gorm.io/gorm"
+)
+
+// UserTokenRepository интерфейс для работы с одноразовыми токенами пользователей
+type UserTokenRepository interface {
+	FindByHash(hash string, purpose string) (*models.UserToken, error)
+	Save(token *models.UserToken) error
+	MarkUsed(id uint) (bool, error)
+	InvalidateAll(userID uint, purpose string) error
+}
+
+// userTokenRepository реализация UserTokenRepository
+type userTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewUserTokenRepository создает новый экземпляр репозитория одноразовых токенов
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &userTokenRepository{db: db}
+}
+
+// FindByHash находит токен по хешу и назначению
+func (r *userTokenRepository) FindByHash(hash string, purpose string) (*models.UserToken, error) {
+	var token models.UserToken
+	if err := r.db.Where("token_hash = ? AND purpose = ?", hash, purpose).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Save сохраняет новый токен
+func (r *userTokenRepository) Save(token *models.UserToken) error {
+	return r.db.Create(token).Error
+}
+
+// MarkUsed помечает токен использованным
+// Возвращает false, если токен уже был использован
+func (r *userTokenRepository) MarkUsed(id uint) (bool, error) {
+	result := r.db.Model(&models.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// InvalidateAll помечает использованными все активные токены пользователя с указанным назначением
+func (r *userTokenRepository) InvalidateAll(userID uint, purpose string) error {
+	return r.db.Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Типы значений ключей additional_fields
+const (
+	FieldTypeString = "string"
+	FieldTypeNumber = "number"
+	FieldTypeBool   = "bool"
+	FieldTypeArray  = "array" // массив строк
+)
+
+// Операторы условий на ключи additional_fields
+const (
+	FieldOpEq  = "eq"
+	FieldOpIn  = "in"
+	FieldOpGt  = "gt"
+	FieldOpGte = "gte"
+	FieldOpLt  = "lt"
+	FieldOpLte = "lte"
+)
+
+// fieldComparisons SQL операторы сравнения для условий на числовые ключи
+var fieldComparisons = map[string]string{
+	FieldOpEq:  "=",
+	FieldOpGt:  ">",
+	FieldOpGte: ">=",
+	FieldOpLt:  "<",
+	FieldOpLte: "<=",
+}
+
+// salaryRate подзапрос курса валюты вакансии к BaseCurrency, NULL если курса нет
+const salaryRate = "(SELECT er.rate FROM exchange_rate er WHERE er.currency = vacancy.currency)"
+
+// salaryBase выражение нижней границы зарплаты вакансии в BaseCurrency
+const salaryBase = "COALESCE(vacancy.salary_from, vacancy.salary_to) * " + salaryRate
+
+// AdditionalField ключ additional_fields, по которому разрешены фильтрация и сортировка
+type AdditionalField struct {
+	Key  string
+	Type string
+}
+
+// path возвращает JSON путь к ключу
+func (f AdditionalField) path() string {
+	return "$." + f.Key
+}
+
+// FieldCondition условие на значение ключа additional_fields
+// Для FieldOpIn значения объединяются через ИЛИ, для остальных операторов используется первое значение.
+// Числовые значения передаются как float64, логические - как bool, остальные - как string
+type FieldCondition struct {
+	Field  AdditionalField
+	Op     string
+	Values []interface{}
+}
+
+// VacancyFilter условия отбора вакансий в публичных списках
+// Пустые поля не ограничивают выборку
+type VacancyFilter struct {
+	SalaryMin     *float64 // в BaseCurrency: верхняя граница вилки не ниже
+	SalaryMax     *float64 // в BaseCurrency: нижняя граница вилки не выше
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Title         string // подстрока названия вакансии или любого из его переводов
+	Fields        []FieldCondition
+}
+
+// VacancySort порядок сортировки списка вакансий
+// Если задан JSONField, сортировка идет по ключу additional_fields, иначе по колонке Column.
+// Relevance сортирует результаты поиска по релевантности
+type VacancySort struct {
+	Column    string
+	JSONField *AdditionalField
+	Relevance bool
+	Desc      bool
+}
+
+// scope возвращает условия фильтра для запроса к таблице vacancy
+// Все значения передаются параметрами запроса
+func (f VacancyFilter) scope() func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		// Вилка пересекается с диапазоном фильтра, вакансии без курса валюты не подходят
+		if f.SalaryMin != nil {
+			db = db.Where("COALESCE(vacancy.salary_to, vacancy.salary_from) * "+salaryRate+" >= ?", *f.SalaryMin)
+		}
+		if f.SalaryMax != nil {
+			db = db.Where("COALESCE(vacancy.salary_from, vacancy.salary_to) * "+salaryRate+" <= ?", *f.SalaryMax)
+		}
+		if f.CreatedAfter != nil {
+			db = db.Where("vacancy.created_at >= ?", *f.CreatedAfter)
+		}
+		if f.CreatedBefore != nil {
+			db = db.Where("vacancy.created_at < ?", *f.CreatedBefore)
+		}
+		if f.Title != "" {
+			pattern := "%" + escapeLike(f.Title) + "%"
+			db = db.Where("(vacancy.title LIKE ? OR vacancy.id IN ("+
+				"SELECT vt.vacancy_id FROM vacancy_translation vt WHERE vt.title LIKE ?))", pattern, pattern)
+		}
+		for _, condition := range f.Fields {
+			db = db.Where(condition.expression())
+		}
+		return db
+	}
+}
+
+// expression возвращает SQL условие с JSON_EXTRACT или JSON_CONTAINS
+// Путь к ключу и значения передаются параметрами запроса
+func (c FieldCondition) expression() clause.Expr {
+	path := c.Field.path()
+
+	switch c.Field.Type {
+	case FieldTypeArray:
+		parts := make([]string, 0, len(c.Values))
+		vars := make([]interface{}, 0, len(c.Values)*2)
+		for _, value := range c.Values {
+			parts = append(parts, "JSON_CONTAINS(vacancy.additional_fields, JSON_QUOTE(?), ?)")
+			vars = append(vars, value, path)
+		}
+		return clause.Expr{SQL: "(" + strings.Join(parts, " OR ") + ")", Vars: vars}
+	case FieldTypeBool:
+		value := "false"
+		if enabled, _ := c.Values[0].(bool); enabled {
+			value = "true"
+		}
+		return clause.Expr{SQL: "JSON_EXTRACT(vacancy.additional_fields, ?) = CAST(? AS JSON)", Vars: []interface{}{path, value}}
+	}
+
+	column := c.Field.column()
+	if c.Op == FieldOpIn {
+		return clause.Expr{SQL: column + " IN ?", Vars: []interface{}{path, c.Values}}
+	}
+	return clause.Expr{SQL: column + " " + fieldComparisons[c.Op] + " ?", Vars: []interface{}{path, c.Values[0]}}
+}
+
+// column возвращает SQL выражение значения скалярного ключа, путь передается параметром
+func (f AdditionalField) column() string {
+	if f.Type == FieldTypeNumber {
+		return "CAST(JSON_EXTRACT(vacancy.additional_fields, ?) AS DECIMAL(20,6))"
+	}
+	return "JSON_UNQUOTE(JSON_EXTRACT(vacancy.additional_fields, ?))"
+}
+
+// escapeLike экранирует служебные символы шаблона LIKE
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
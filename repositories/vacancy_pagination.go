@@ -0,0 +1,340 @@
+package repositories
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"vakansii-back-go/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// vacancyColumns колонки таблицы vacancy, которые можно выбрать в списках
+var vacancyColumns = map[string]bool{
+	"title":             true,
+	"description":       true,
+	"language":          true,
+	"salary_from":       true,
+	"salary_to":         true,
+	"currency":          true,
+	"salary_gross":      true,
+	"additional_fields": true,
+	"user_id":           true,
+	"company_id":        true,
+	"status":            true,
+	"version":           true,
+	"publish_at":        true,
+	"expires_at":        true,
+	"created_at":        true,
+	"updated_at":        true,
+}
+
+// vacancySortColumns колонки, по которым разрешена сортировка списка вакансий
+var vacancySortColumns = map[string]bool{
+	"salary":     true,
+	"created_at": true,
+}
+
+// Keyset позиция в отсортированном списке вакансий: значение ключа сортировки и ID вакансии
+// Backward означает выборку страницы перед позицией, а не после нее
+type Keyset struct {
+	Value    interface{} // nil, если у вакансии нет значения ключа сортировки
+	ID       uint
+	Backward bool
+}
+
+// PageRequest страница списка вакансий: по номеру через OFFSET или по курсору
+// В режиме курсора выбирается на одну вакансию больше размера страницы. Лишняя вакансия, самая дальняя
+// от позиции курсора, означает, что в направлении выборки есть еще одна страница
+type PageRequest struct {
+	Number int
+	Size   int // размер страницы, 0 - PageSize
+	Keyset bool
+	After  *Keyset // позиция курсора, nil - первая страница
+}
+
+// PageSize возвращает размер страницы
+func (p PageRequest) PageSize() int {
+	if p.Size < 1 {
+		return PageSize
+	}
+	return p.Size
+}
+
+// backward проверяет, выбирается ли страница перед позицией курсора
+func (p PageRequest) backward() bool {
+	return p.Keyset && p.After != nil && p.After.Backward
+}
+
+// paginate применяет сортировку и выборку страницы
+// Одинаковые значения ключа сортировки упорядочиваются по ID, поэтому порядок однозначен
+// и новые вакансии не сдвигают страницы, выбранные по курсору
+func paginate(sort VacancySort, page PageRequest) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if !page.Keyset {
+			return db.Clauses(sort.orderBy(false)).Limit(page.PageSize()).Offset((page.Number - 1) * page.PageSize())
+		}
+
+		if page.After != nil {
+			condition := sort.after(*page.After)
+			// Вычисляемый ключ задан в SELECT, поэтому условие на него задается через HAVING
+			if sort.computed() {
+				db = db.Having(condition)
+			} else {
+				db = db.Where(condition)
+			}
+		}
+		return db.Clauses(sort.orderBy(page.backward())).Limit(page.PageSize() + 1)
+	}
+}
+
+// selectFields ограничивает выборку колонками fields, nil - все колонки
+// ID и колонка ключа сортировки выбираются всегда, неизвестные колонки пропускаются.
+// extra - дополнительные выражения SELECT, например вычисляемая релевантность.
+// При сортировке по зарплате в выборку добавляется ее значение в BaseCurrency
+func selectFields(fields []string, sort VacancySort, extra string, args ...interface{}) func(db *gorm.DB) *gorm.DB {
+	if sort.bySalary() {
+		if extra != "" {
+			extra += ", "
+		}
+		extra += salaryBase + " AS salary_base"
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		selection := "vacancy.*"
+		if fields != nil {
+			columns := []string{"vacancy.id"}
+			add := func(column string) {
+				if vacancyColumns[column] && !slices.Contains(columns, "vacancy."+column) {
+					columns = append(columns, "vacancy."+column)
+				}
+			}
+			for _, field := range fields {
+				add(field)
+			}
+			switch {
+			case sort.JSONField != nil:
+				add("additional_fields")
+			case !sort.computed():
+				add(sort.column())
+			}
+			selection = strings.Join(columns, ", ")
+		}
+
+		if extra != "" {
+			return db.Select(selection+", "+extra, args...)
+		}
+		if fields != nil {
+			return db.Select(selection)
+		}
+		return db
+	}
+}
+
+// preloadTranslations загружает переводы вакансий, если выбраны название или описание
+// Из переводов читаются только выбранные тексты
+func preloadTranslations(fields []string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if fields == nil {
+			return db.Preload("Translations")
+		}
+
+		columns := []string{"id", "vacancy_id", "locale"}
+		for _, field := range fields {
+			if (field == "title" || field == "description") && !slices.Contains(columns, field) {
+				columns = append(columns, field)
+			}
+		}
+		if len(columns) == 3 {
+			return db
+		}
+		return db.Preload("Translations", func(db *gorm.DB) *gorm.DB {
+			return db.Select(columns)
+		})
+	}
+}
+
+// column возвращает колонку сортировки, недопустимая колонка заменяется на created_at
+func (s VacancySort) column() string {
+	if !vacancySortColumns[s.Column] {
+		return "created_at"
+	}
+	return s.Column
+}
+
+// bySalary проверяет, идет ли сортировка по зарплате
+// Зарплаты в разных валютах сравниваются по нижней границе вилки в BaseCurrency
+func (s VacancySort) bySalary() bool {
+	return !s.Relevance && s.JSONField == nil && s.column() == "salary"
+}
+
+// computed проверяет, вычисляется ли ключ сортировки в SELECT
+func (s VacancySort) computed() bool {
+	return s.Relevance || s.bySalary()
+}
+
+// key возвращает SQL выражение ключа сортировки и его параметры
+func (s VacancySort) key() (string, []interface{}) {
+	switch {
+	case s.Relevance:
+		return "relevance_score", nil
+	case s.bySalary():
+		return "salary_base", nil
+	case s.JSONField != nil:
+		return s.JSONField.column(), []interface{}{s.JSONField.path()}
+	}
+	return "vacancy." + s.column(), nil
+}
+
+// String возвращает описание сортировки, к которому привязан курсор
+func (s VacancySort) String() string {
+	if s.Relevance {
+		return "relevance"
+	}
+
+	name := s.column()
+	if s.JSONField != nil {
+		name = "af." + s.JSONField.Key
+	}
+	if s.Desc {
+		return name + ":desc"
+	}
+	return name + ":asc"
+}
+
+// orderBy возвращает выражение сортировки с ID вакансии в качестве второго ключа
+// backward меняет направление на обратное для выборки страницы перед курсором
+func (s VacancySort) orderBy(backward bool) clause.OrderBy {
+	direction := " ASC"
+	if s.Desc != backward {
+		direction = " DESC"
+	}
+
+	key, vars := s.key()
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:  key + direction + ", vacancy.id" + direction,
+		Vars: vars,
+	}}
+}
+
+// after возвращает условие выборки вакансий, которые в порядке сортировки идут за позицией курсора
+// В MySQL NULL меньше любого значения: по возрастанию вакансии без ключа идут первыми, по убыванию - последними
+func (s VacancySort) after(position Keyset) clause.Expr {
+	key, keyVars := s.key()
+	desc := s.Desc != position.Backward
+	op := " > "
+	if desc {
+		op = " < "
+	}
+
+	// Выражение ключа стоит в начале каждой части условия, поэтому его параметры идут первыми
+	var parts []string
+	var vars []interface{}
+	add := func(sql string, args ...interface{}) {
+		parts = append(parts, sql)
+		vars = append(vars, keyVars...)
+		vars = append(vars, args...)
+	}
+
+	if position.Value == nil {
+		add("("+key+" IS NULL AND vacancy.id"+op+"?)", position.ID)
+		if !desc {
+			add(key + " IS NOT NULL")
+		}
+	} else {
+		add(key+op+"?", position.Value)
+		add("("+key+" = ? AND vacancy.id"+op+"?)", position.Value, position.ID)
+		if desc {
+			add(key + " IS NULL")
+		}
+	}
+
+	return clause.Expr{SQL: "(" + strings.Join(parts, " OR ") + ")", Vars: vars}
+}
+
+// KeyOf возвращает значение ключа сортировки вакансии в том виде, в каком его сравнивает MySQL
+func (s VacancySort) KeyOf(vacancy *models.Vacancy) interface{} {
+	switch {
+	case s.Relevance:
+		return vacancy.Relevance
+	case s.JSONField != nil:
+		return s.JSONField.valueOf(vacancy.AdditionalFields)
+	case s.bySalary():
+		if vacancy.SalaryBase == nil {
+			return nil
+		}
+		return *vacancy.SalaryBase
+	}
+	return vacancy.CreatedAt
+}
+
+// DecodeKey восстанавливает значение ключа сортировки, сериализованное в JSON
+func (s VacancySort) DecodeKey(raw json.RawMessage) (interface{}, error) {
+	if string(raw) == "null" {
+		return nil, nil
+	}
+
+	var err error
+	switch {
+	case s.computed() || (s.JSONField != nil && s.JSONField.Type == FieldTypeNumber):
+		var value float64
+		err = json.Unmarshal(raw, &value)
+		return value, err
+	case s.JSONField != nil:
+		var value string
+		err = json.Unmarshal(raw, &value)
+		return value, err
+	}
+
+	var value time.Time
+	err = json.Unmarshal(raw, &value)
+	return value, err
+}
+
+// valueOf возвращает значение ключа так же, как его вычисляет выражение column
+// Числа округляются до точности DECIMAL(20,6), прочие значения строк приводятся к тексту JSON
+func (f AdditionalField) valueOf(fields models.JSONB) interface{} {
+	value, ok := fields[f.Key]
+	if !ok {
+		return nil
+	}
+
+	if f.Type == FieldTypeNumber {
+		var number float64
+		switch v := value.(type) {
+		case nil:
+			return nil
+		case float64:
+			number = v
+		case string:
+			number, _ = strconv.ParseFloat(v, 64)
+		case bool:
+			if v {
+				number = 1
+			}
+		}
+		return math.Round(number*1e6) / 1e6
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	text, _ := json.Marshal(value)
+	return string(text)
+}
+
+// arrange возвращает вакансии страницы в порядке сортировки
+// Страница перед курсором выбирается в обратном порядке и разворачивается
+func (p PageRequest) arrange(vacancies []models.Vacancy) []models.Vacancy {
+	if p.backward() {
+		slices.Reverse(vacancies)
+	}
+	return vacancies
+}
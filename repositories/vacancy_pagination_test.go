@@ -0,0 +1,315 @@
+package repositories
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"vakansii-back-go/models"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// dryRunDB возвращает соединение, которое только строит SQL без обращения к базе
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "user:password@tcp(127.0.0.1:3306)/test",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("gorm.Open() error = %v", err)
+	}
+	return db
+}
+
+func TestVacancySortAfter(t *testing.T) {
+	createdAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	salary := VacancySort{Column: "salary"}
+	salaryDesc := VacancySort{Column: "salary", Desc: true}
+	level := &AdditionalField{Key: "level", Type: FieldTypeNumber}
+
+	tests := []struct {
+		name     string
+		sort     VacancySort
+		position Keyset
+		wantSQL  string
+		wantVars []interface{}
+	}{
+		{
+			name:     "asc",
+			sort:     VacancySort{Column: "created_at"},
+			position: Keyset{Value: createdAt, ID: 5},
+			wantSQL:  "(vacancy.created_at > ? OR (vacancy.created_at = ? AND vacancy.id > ?))",
+			wantVars: []interface{}{createdAt, createdAt, uint(5)},
+		},
+		{
+			name:     "desc",
+			sort:     VacancySort{Column: "created_at", Desc: true},
+			position: Keyset{Value: createdAt, ID: 5},
+			wantSQL:  "(vacancy.created_at < ? OR (vacancy.created_at = ? AND vacancy.id < ?) OR vacancy.created_at IS NULL)",
+			wantVars: []interface{}{createdAt, createdAt, uint(5)},
+		},
+		{
+			name:     "asc backward",
+			sort:     salary,
+			position: Keyset{Value: 1000.0, ID: 5, Backward: true},
+			wantSQL:  "(salary_base < ? OR (salary_base = ? AND vacancy.id < ?) OR salary_base IS NULL)",
+			wantVars: []interface{}{1000.0, 1000.0, uint(5)},
+		},
+		{
+			name:     "desc backward",
+			sort:     salaryDesc,
+			position: Keyset{Value: 1000.0, ID: 5, Backward: true},
+			wantSQL:  "(salary_base > ? OR (salary_base = ? AND vacancy.id > ?))",
+			wantVars: []interface{}{1000.0, 1000.0, uint(5)},
+		},
+		{
+			name:     "asc null key",
+			sort:     salary,
+			position: Keyset{ID: 5},
+			wantSQL:  "((salary_base IS NULL AND vacancy.id > ?) OR salary_base IS NOT NULL)",
+			wantVars: []interface{}{uint(5)},
+		},
+		{
+			name:     "desc null key",
+			sort:     salaryDesc,
+			position: Keyset{ID: 5},
+			wantSQL:  "((salary_base IS NULL AND vacancy.id < ?))",
+			wantVars: []interface{}{uint(5)},
+		},
+		{
+			name:     "asc null key backward",
+			sort:     salary,
+			position: Keyset{ID: 5, Backward: true},
+			wantSQL:  "((salary_base IS NULL AND vacancy.id < ?))",
+			wantVars: []interface{}{uint(5)},
+		},
+		{
+			name:     "json key parameters precede values",
+			sort:     VacancySort{JSONField: level, Desc: true},
+			position: Keyset{Value: 2.5, ID: 5},
+			wantSQL: "(CAST(JSON_EXTRACT(vacancy.additional_fields, ?) AS DECIMAL(20,6)) < ?" +
+				" OR (CAST(JSON_EXTRACT(vacancy.additional_fields, ?) AS DECIMAL(20,6)) = ? AND vacancy.id < ?)" +
+				" OR CAST(JSON_EXTRACT(vacancy.additional_fields, ?) AS DECIMAL(20,6)) IS NULL)",
+			wantVars: []interface{}{"$.level", 2.5, "$.level", 2.5, uint(5), "$.level"},
+		},
+		{
+			name:     "relevance",
+			sort:     VacancySort{Relevance: true, Desc: true},
+			position: Keyset{Value: 0.75, ID: 5},
+			wantSQL:  "(relevance_score < ? OR (relevance_score = ? AND vacancy.id < ?) OR relevance_score IS NULL)",
+			wantVars: []interface{}{0.75, 0.75, uint(5)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.sort.after(tt.position)
+			if got.SQL != tt.wantSQL {
+				t.Errorf("after() SQL = %s\nwant %s", got.SQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(got.Vars, tt.wantVars) {
+				t.Errorf("after() vars = %#v, want %#v", got.Vars, tt.wantVars)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	db := dryRunDB(t)
+	createdAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		sort    VacancySort
+		page    PageRequest
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "offset",
+			sort: VacancySort{Column: "created_at", Desc: true},
+			page: PageRequest{Number: 3, Size: 10},
+			want: []string{"ORDER BY vacancy.created_at DESC, vacancy.id DESC", "LIMIT 10 OFFSET 20"},
+		},
+		{
+			name:    "keyset first page",
+			sort:    VacancySort{Column: "created_at"},
+			page:    PageRequest{Size: 10, Keyset: true},
+			want:    []string{"ORDER BY vacancy.created_at ASC, vacancy.id ASC LIMIT 11"},
+			notWant: []string{"OFFSET", "vacancy.id >"},
+		},
+		{
+			name:    "keyset stored key uses where",
+			sort:    VacancySort{Column: "created_at"},
+			page:    PageRequest{Size: 10, Keyset: true, After: &Keyset{Value: createdAt, ID: 7}},
+			want:    []string{"WHERE ((vacancy.created_at >", "vacancy.id > 7", "LIMIT 11"},
+			notWant: []string{"HAVING"},
+		},
+		{
+			name: "keyset computed key uses having",
+			sort: VacancySort{Column: "salary", Desc: true},
+			page: PageRequest{Size: 10, Keyset: true, After: &Keyset{Value: 1000.0, ID: 7}},
+			want: []string{
+				"HAVING (salary_base < 1000",
+				"ORDER BY salary_base DESC, vacancy.id DESC LIMIT 11",
+			},
+			notWant: []string{"WHERE ("},
+		},
+		{
+			name: "keyset backward reverses order",
+			sort: VacancySort{Column: "created_at", Desc: true},
+			page: PageRequest{Size: 10, Keyset: true, After: &Keyset{Value: createdAt, ID: 7, Backward: true}},
+			want: []string{"vacancy.id > 7", "ORDER BY vacancy.created_at ASC, vacancy.id ASC LIMIT 11"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				var vacancies []models.Vacancy
+				return tx.Model(&models.Vacancy{}).Scopes(paginate(tt.sort, tt.page)).Find(&vacancies)
+			})
+			for _, part := range tt.want {
+				if !strings.Contains(sql, part) {
+					t.Errorf("SQL %s\ndoes not contain %q", sql, part)
+				}
+			}
+			for _, part := range tt.notWant {
+				if strings.Contains(sql, part) {
+					t.Errorf("SQL %s\ncontains %q", sql, part)
+				}
+			}
+		})
+	}
+}
+
+func TestVacancySortKeyRoundTrip(t *testing.T) {
+	salaryBase := 150000.5
+	vacancy := &models.Vacancy{
+		ID:               3,
+		CreatedAt:        time.Date(2025, 1, 10, 12, 0, 0, 123456789, time.UTC),
+		Relevance:        1.25,
+		SalaryBase:       &salaryBase,
+		AdditionalFields: models.JSONB{"city": "Москва", "level": 2.0000004},
+	}
+
+	tests := []struct {
+		name string
+		sort VacancySort
+		want interface{}
+	}{
+		{"created_at", VacancySort{Column: "created_at"}, vacancy.CreatedAt},
+		{"relevance", VacancySort{Relevance: true}, 1.25},
+		{"salary", VacancySort{Column: "salary"}, 150000.5},
+		{"json string", VacancySort{JSONField: &AdditionalField{Key: "city", Type: FieldTypeString}}, "Москва"},
+		{"json number", VacancySort{JSONField: &AdditionalField{Key: "level", Type: FieldTypeNumber}}, 2.0},
+		{"json missing", VacancySort{JSONField: &AdditionalField{Key: "team", Type: FieldTypeString}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := json.Marshal(tt.sort.KeyOf(vacancy))
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			got, err := tt.sort.DecodeKey(raw)
+			if err != nil {
+				t.Fatalf("DecodeKey(%s) error = %v", raw, err)
+			}
+			if want, ok := tt.want.(time.Time); ok {
+				if gotTime, ok := got.(time.Time); !ok || !gotTime.Equal(want) {
+					t.Errorf("DecodeKey(%s) = %#v, want %v", raw, got, want)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodeKey(%s) = %#v, want %#v", raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVacancySortKeyOfWithoutSalary(t *testing.T) {
+	if got := (VacancySort{Column: "salary"}).KeyOf(&models.Vacancy{ID: 1}); got != nil {
+		t.Errorf("KeyOf() = %#v, want nil", got)
+	}
+}
+
+func TestVacancySortDecodeKeyRejectsWrongType(t *testing.T) {
+	tests := []struct {
+		name string
+		sort VacancySort
+		raw  string
+	}{
+		{"created_at", VacancySort{Column: "created_at"}, `12`},
+		{"salary", VacancySort{Column: "salary"}, `"abc"`},
+		{"json string", VacancySort{JSONField: &AdditionalField{Key: "city", Type: FieldTypeString}}, `1`},
+		{"json number", VacancySort{JSONField: &AdditionalField{Key: "level", Type: FieldTypeNumber}}, `"2"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.sort.DecodeKey(json.RawMessage(tt.raw)); err == nil {
+				t.Errorf("DecodeKey(%s) error = nil", tt.raw)
+			}
+		})
+	}
+}
+
+func TestAdditionalFieldValueOf(t *testing.T) {
+	number := AdditionalField{Key: "k", Type: FieldTypeNumber}
+	text := AdditionalField{Key: "k", Type: FieldTypeString}
+
+	tests := []struct {
+		name  string
+		field AdditionalField
+		value interface{}
+		want  interface{}
+	}{
+		{"number rounded to decimal scale", number, 1.23456789, 1.234568},
+		{"number half up", number, 0.0000005, 0.000001},
+		{"number from string", number, "42.5", 42.5},
+		{"number from bool", number, true, 1.0},
+		{"number null", number, nil, nil},
+		{"string", text, "Москва", "Москва"},
+		{"string from number", text, 3.0, "3"},
+		{"string from bool", text, true, "true"},
+		{"string from array", text, []interface{}{"go"}, `["go"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.field.valueOf(models.JSONB{"k": tt.value})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("valueOf(%#v) = %#v, want %#v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	if got := number.valueOf(models.JSONB{}); got != nil {
+		t.Errorf("valueOf() for missing key = %#v, want nil", got)
+	}
+}
+
+func TestVacancySortString(t *testing.T) {
+	tests := []struct {
+		sort VacancySort
+		want string
+	}{
+		{VacancySort{Column: "created_at", Desc: true}, "created_at:desc"},
+		{VacancySort{Column: "salary"}, "salary:asc"},
+		{VacancySort{Column: "unknown"}, "created_at:asc"},
+		{VacancySort{Relevance: true, Desc: true}, "relevance"},
+		{VacancySort{JSONField: &AdditionalField{Key: "level", Type: FieldTypeNumber}, Desc: true}, "af.level:desc"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.sort.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
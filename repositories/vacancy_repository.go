@@ -1,24 +1,42 @@
 package repositories
 
 import (
-	"fmt"
+	"errors"
 	"strings"
+	"time"
 	"vakansii-back-go/models"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrVersionConflict возвращается, когда вакансия была изменена после чтения
+var ErrVersionConflict = errors.New("vacancy version conflict")
+
 // VacancyRepository интерфейс для работы с вакансиями
 type VacancyRepository interface {
 	FindByID(id uint) (*models.Vacancy, error)
-	FindAll(page int, sortBy string, sortOrder string) ([]models.Vacancy, int64, error)
-	Save(vacancy *models.Vacancy) error
-	Update(vacancy *models.Vacancy) error
-	Delete(id uint) error
+	FindAll(filter VacancyFilter, sort VacancySort, page PageRequest, fields []string) ([]models.Vacancy, int64, error)
+	Save(vacancy *models.Vacancy, inTx TxFunc) error
+	Update(vacancy *models.Vacancy, inTx TxFunc) error
+	Delete(vacancy *models.Vacancy) error
 	GetTotalCount() (int64, error)
-	Search(query string, page int, sortOrder string) ([]models.Vacancy, int64, error)
+	LastModified(now time.Time) (time.Time, error)
+	Search(query string, filter VacancyFilter, sort VacancySort, page PageRequest, fields []string) ([]models.Vacancy, int64, error)
+	FindByUser(userID uint, page int) ([]models.Vacancy, int64, error)
+	FindByCompany(companyID uint, page int) ([]models.Vacancy, int64, error)
+	PublishDue(now time.Time) (int64, error)
+	ExpireDue(now time.Time) (int64, error)
+	FindDeletedByID(id uint) (*models.Vacancy, error)
+	FindDeleted(userID *uint, page int) ([]models.Vacancy, int64, error)
+	Restore(id uint) error
+	PurgeDeleted(before time.Time) (int64, error)
 }
 
+// TxFunc действие, которое выполняется в транзакции сохранения вакансии
+// Ошибка действия откатывает и само сохранение
+type TxFunc func(tx *gorm.DB) error
+
 // vacancyRepository реализация VacancyRepository
 type vacancyRepository struct {
 	db *gorm.DB
@@ -34,63 +52,115 @@ func NewVacancyRepository(db *gorm.DB) VacancyRepository {
 // FindByID находит вакансию по ID
 func (r *vacancyRepository) FindByID(id uint) (*models.Vacancy, error) {
 	var vacancy models.Vacancy
-	if err := r.db.First(&vacancy, id).Error; err != nil {
+	if err := r.db.Preload("Company").Preload("Translations").First(&vacancy, id).Error; err != nil {
 		return nil, err
 	}
 	return &vacancy, nil
 }
 
-// FindAll получает все вакансии с пагинацией и сортировкой
-func (r *vacancyRepository) FindAll(page int, sortBy string, sortOrder string) ([]models.Vacancy, int64, error) {
+// FindAll получает все вакансии, подходящие под фильтр, с пагинацией и сортировкой
+// fields ограничивает выбираемые колонки, nil - вакансии загружаются целиком
+func (r *vacancyRepository) FindAll(filter VacancyFilter, sort VacancySort, page PageRequest, fields []string) ([]models.Vacancy, int64, error) {
 	var vacancies []models.Vacancy
 	var total int64
 
+	// Публично доступны только опубликованные вакансии
+	db := r.db.Model(&models.Vacancy{}).Scopes(publiclyVisible(time.Now()), filter.scope())
+
 	// Считаем общее количество
-	if err := r.db.Model(&models.Vacancy{}).Count(&total).Error; err != nil {
+	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Валидация полей сортировки
-	allowedSortFields := map[string]bool{
-		"salary":     true,
-		"created_at": true,
-	}
-	if !allowedSortFields[sortBy] {
-		sortBy = "created_at"
+	if err := db.Scopes(selectFields(fields, sort, ""), preloadTranslations(fields), paginate(sort, page)).Find(&vacancies).Error; err != nil {
+		return nil, 0, err
 	}
 
-	// Валидация порядка сортировки
-	if sortOrder != "asc" && sortOrder != "desc" {
-		sortOrder = "desc"
-	}
+	return page.arrange(vacancies), total, nil
+}
 
-	offset := (page - 1) * PageSize
-	orderClause := fmt.Sprintf("%s %s", sortBy, sortOrder)
+// Save сохраняет новую вакансию и выполняет inTx в той же транзакции, nil - без дополнительных действий
+func (r *vacancyRepository) Save(vacancy *models.Vacancy, inTx TxFunc) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(vacancy).Error; err != nil {
+			return err
+		}
+		if inTx != nil {
+			return inTx(tx)
+		}
+		return nil
+	})
+}
 
-	if err := r.db.Order(orderClause).Limit(PageSize).Offset(offset).Find(&vacancies).Error; err != nil {
-		return nil, 0, err
-	}
+// Update обновляет существующую вакансию, если ее версия не изменилась с момента чтения
+// Связанные сущности не сохраняются, изменяются только внешние ключи.
+// Переводы в базе приводятся к переводам вакансии в той же транзакции, затем выполняется inTx
+func (r *vacancyRepository) Update(vacancy *models.Vacancy, inTx TxFunc) error {
+	version := vacancy.Version
+	vacancy.Version++
 
-	return vacancies, total, nil
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(vacancy).
+			Select("*").
+			Omit("User", "Company", "Translations").
+			Where("version = ?", version).
+			Updates(vacancy)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		if err := saveTranslations(tx, vacancy); err != nil {
+			return err
+		}
+		if inTx != nil {
+			return inTx(tx)
+		}
+		return nil
+	})
+	if err != nil {
+		vacancy.Version = version
+		return err
+	}
+	return nil
 }
 
-// Save сохраняет новую вакансию
-func (r *vacancyRepository) Save(vacancy *models.Vacancy) error {
-	return r.db.Create(vacancy).Error
-}
+// saveTranslations удаляет переводы вакансии, которых больше нет, и сохраняет остальные
+func saveTranslations(tx *gorm.DB, vacancy *models.Vacancy) error {
+	locales := make([]string, 0, len(vacancy.Translations))
+	for i := range vacancy.Translations {
+		vacancy.Translations[i].VacancyID = vacancy.ID
+		locales = append(locales, vacancy.Translations[i].Locale)
+	}
 
-// Update обновляет существующую вакансию
-func (r *vacancyRepository) Update(vacancy *models.Vacancy) error {
-	return r.db.Save(vacancy).Error
+	stale := tx.Where("vacancy_id = ?", vacancy.ID)
+	if len(locales) > 0 {
+		stale = stale.Where("locale NOT IN ?", locales)
+	}
+	if err := stale.Delete(&models.VacancyTranslation{}).Error; err != nil {
+		return err
+	}
+
+	if len(vacancy.Translations) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "vacancy_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "description", "updated_at"}),
+	}).Create(&vacancy.Translations).Error
 }
 
-// Delete удаляет вакансию по ID
-func (r *vacancyRepository) Delete(id uint) error {
-	result := r.db.Delete(&models.Vacancy{}, id)
+// Delete перемещает вакансию в корзину (мягкое удаление), если ее версия не изменилась с момента чтения
+func (r *vacancyRepository) Delete(vacancy *models.Vacancy) error {
+	result := r.db.Where("version = ?", vacancy.Version).Delete(&models.Vacancy{}, vacancy.ID)
+	if result.Error != nil {
+		return result.Error
+	}
 	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+		return ErrVersionConflict
 	}
-	return result.Error
+	return nil
 }
 
 // GetTotalCount возвращает общее количество вакансий
@@ -102,8 +172,41 @@ func (r *vacancyRepository) GetTotalCount() (int64, error) {
 	return count, nil
 }
 
-// Search выполняет полнотекстовый поиск по вакансиям
-func (r *vacancyRepository) Search(query string, page int, sortOrder string) ([]models.Vacancy, int64, error) {
+// LastModified возвращает время последнего изменения, которое могло затронуть публичные списки вакансий:
+// изменения и удаления вакансий, наступившие даты публикации и снятия, а также изменения курсов валют.
+// Учитываются все вакансии, а не только подходящие под фильтр: вакансия, которая после изменения
+// перестала подходить под фильтр, тоже меняет список
+func (r *vacancyRepository) LastModified(now time.Time) (time.Time, error) {
+	var moments struct {
+		UpdatedAt *time.Time
+		DeletedAt *time.Time
+		PublishAt *time.Time
+		ExpiresAt *time.Time
+		RateAt    *time.Time
+	}
+	err := r.db.Unscoped().Model(&models.Vacancy{}).
+		Select(`MAX(updated_at) AS updated_at, MAX(deleted_at) AS deleted_at,
+			MAX(CASE WHEN publish_at <= ? THEN publish_at END) AS publish_at,
+			MAX(CASE WHEN expires_at <= ? THEN expires_at END) AS expires_at,
+			(SELECT MAX(updated_at) FROM exchange_rate) AS rate_at`, now, now).
+		Scan(&moments).Error
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var latest time.Time
+	for _, moment := range []*time.Time{moments.UpdatedAt, moments.DeletedAt, moments.PublishAt, moments.ExpiresAt, moments.RateAt} {
+		if moment != nil && moment.After(latest) {
+			latest = *moment
+		}
+	}
+	return latest, nil
+}
+
+// Search выполняет полнотекстовый поиск по вакансиям, подходящим под фильтр
+// Ищет как по основному тексту вакансии, так и по ее переводам.
+// fields ограничивает выбираемые колонки, nil - вакансии загружаются целиком
+func (r *vacancyRepository) Search(query string, filter VacancyFilter, sort VacancySort, page PageRequest, fields []string) ([]models.Vacancy, int64, error) {
 	var vacancies []models.Vacancy
 	var total int64
 
@@ -111,30 +214,187 @@ func (r *vacancyRepository) Search(query string, page int, sortOrder string) ([]
 
 	// Базовый запрос с FULLTEXT поиском для MySQL
 	db := r.db.Model(&models.Vacancy{}).
-		Where("MATCH(title, description) AGAINST (? IN NATURAL LANGUAGE MODE)", query)
+		Scopes(publiclyVisible(time.Now()), filter.scope()).
+		Where("(MATCH(vacancy.title, vacancy.description) AGAINST (? IN NATURAL LANGUAGE MODE) OR vacancy.id IN ("+
+			"SELECT vt.vacancy_id FROM vacancy_translation vt "+
+			"WHERE MATCH(vt.title, vt.description) AGAINST (? IN NATURAL LANGUAGE MODE)))", query, query)
 
 	// Считаем общее количество результатов
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Применяем сортировку
-	offset := (page - 1) * PageSize
-
-	if sortOrder == "relevance" {
+	if sort.Relevance {
 		// Сортировка по релевантности (для MySQL)
-		db = db.Select("*, MATCH(title, description) AGAINST (? IN NATURAL LANGUAGE MODE) as relevance_score", query).
-			Order("relevance_score DESC")
-	} else if sortOrder == "asc" {
-		db = db.Order("created_at ASC")
+		// Релевантность вакансии - лучшее совпадение среди основного текста и переводов
+		db = db.Scopes(selectFields(fields, sort, "GREATEST("+
+			"MATCH(vacancy.title, vacancy.description) AGAINST (? IN NATURAL LANGUAGE MODE), "+
+			"COALESCE((SELECT MAX(MATCH(vt.title, vt.description) AGAINST (? IN NATURAL LANGUAGE MODE)) "+
+			"FROM vacancy_translation vt WHERE vt.vacancy_id = vacancy.id), 0)) as relevance_score", query, query))
 	} else {
-		db = db.Order("created_at DESC")
+		db = db.Scopes(selectFields(fields, sort, ""))
+	}
+
+	if err := db.Scopes(preloadTranslations(fields), paginate(sort, page)).Find(&vacancies).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return page.arrange(vacancies), total, nil
+}
+
+// FindByUser получает вакансии, принадлежащие пользователю, в любом статусе
+func (r *vacancyRepository) FindByUser(userID uint, page int) ([]models.Vacancy, int64, error) {
+	var vacancies []models.Vacancy
+	var total int64
+
+	db := r.db.Model(&models.Vacancy{}).Where("user_id = ?", userID)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * PageSize
+
+	if err := db.Order("created_at DESC").Limit(PageSize).Offset(offset).Find(&vacancies).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return vacancies, total, nil
+}
+
+// FindByCompany получает опубликованные вакансии компании
+func (r *vacancyRepository) FindByCompany(companyID uint, page int) ([]models.Vacancy, int64, error) {
+	var vacancies []models.Vacancy
+	var total int64
+
+	db := r.db.Model(&models.Vacancy{}).
+		Where("company_id = ?", companyID).
+		Scopes(publiclyVisible(time.Now()))
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * PageSize
+
+	if err := db.Order("created_at DESC").Limit(PageSize).Offset(offset).Find(&vacancies).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return vacancies, total, nil
+}
+
+// PublishDue публикует черновики, время публикации которых наступило
+func (r *vacancyRepository) PublishDue(now time.Time) (int64, error) {
+	result := r.db.Model(&models.Vacancy{}).
+		Where("status = ? AND publish_at IS NOT NULL AND publish_at <= ?", models.VacancyStatusDraft, now).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Updates(map[string]interface{}{
+			"status":  models.VacancyStatusPublished,
+			"version": gorm.Expr("version + 1"),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// ExpireDue закрывает вакансии, срок размещения которых истек
+// Просроченные запланированные черновики переносятся в архив
+func (r *vacancyRepository) ExpireDue(now time.Time) (int64, error) {
+	closed := r.db.Model(&models.Vacancy{}).
+		Where("status IN ? AND expires_at IS NOT NULL AND expires_at <= ?",
+			[]string{models.VacancyStatusPublished, models.VacancyStatusPaused}, now).
+		Updates(map[string]interface{}{
+			"status":  models.VacancyStatusClosed,
+			"version": gorm.Expr("version + 1"),
+		})
+	if closed.Error != nil {
+		return 0, closed.Error
+	}
+
+	archived := r.db.Model(&models.Vacancy{}).
+		Where("status = ? AND publish_at IS NOT NULL AND expires_at IS NOT NULL AND expires_at <= ?",
+			models.VacancyStatusDraft, now).
+		Updates(map[string]interface{}{
+			"status":  models.VacancyStatusArchived,
+			"version": gorm.Expr("version + 1"),
+		})
+	if archived.Error != nil {
+		return closed.RowsAffected, archived.Error
+	}
+
+	return closed.RowsAffected + archived.RowsAffected, nil
+}
+
+// FindDeletedByID находит удаленную вакансию по ID
+func (r *vacancyRepository) FindDeletedByID(id uint) (*models.Vacancy, error) {
+	var vacancy models.Vacancy
+	if err := r.db.Unscoped().Preload("Company").
+		Where("deleted_at IS NOT NULL").
+		First(&vacancy, id).Error; err != nil {
+		return nil, err
+	}
+	return &vacancy, nil
+}
+
+// FindDeleted получает вакансии из корзины
+// Если userID задан, возвращаются удаленные вакансии пользователя и компаний, в которых он состоит,
+// иначе удаленные вакансии всех пользователей
+func (r *vacancyRepository) FindDeleted(userID *uint, page int) ([]models.Vacancy, int64, error) {
+	var vacancies []models.Vacancy
+	var total int64
+
+	db := r.db.Unscoped().Model(&models.Vacancy{}).Where("deleted_at IS NOT NULL")
+	if userID != nil {
+		db = db.Where("user_id = ? OR company_id IN (SELECT company_id FROM company_member WHERE user_id = ?)", *userID, *userID)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
 
-	// Применяем пагинацию
-	if err := db.Limit(PageSize).Offset(offset).Find(&vacancies).Error; err != nil {
+	offset := (page - 1) * PageSize
+
+	if err := db.Order("deleted_at DESC").Limit(PageSize).Offset(offset).Find(&vacancies).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return vacancies, total, nil
 }
+
+// Restore возвращает вакансию из корзины
+func (r *vacancyRepository) Restore(id uint) error {
+	result := r.db.Unscoped().Model(&models.Vacancy{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		UpdateColumns(map[string]interface{}{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// PurgeDeleted окончательно удаляет вакансии, перемещенные в корзину раньше before
+// Отклики на эти вакансии удаляются каскадно
+func (r *vacancyRepository) PurgeDeleted(before time.Time) (int64, error) {
+	result := r.db.Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&models.Vacancy{})
+	return result.RowsAffected, result.Error
+}
+
+// publiclyVisible ограничивает выборку вакансиями, видимыми публично в момент now
+// Запланированный черновик считается опубликованным с момента publish_at,
+// поэтому выдача корректна, даже если планировщик еще не обновил статус
+func publiclyVisible(now time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Where("status = ? OR (status = ? AND publish_at IS NOT NULL)",
+				models.VacancyStatusPublished, models.VacancyStatusDraft).
+			Where("publish_at IS NULL OR publish_at <= ?", now).
+			Where("expires_at IS NULL OR expires_at > ?", now)
+	}
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"vakansii-back-go/models"
+
+	"gorm.io/gorm"
+)
+
+// VacancyRevisionRepository интерфейс для работы с ревизиями вакансий
+type VacancyRevisionRepository interface {
+	FindByVacancy(vacancyID uint, page int) ([]models.VacancyRevision, int64, error)
+	FindByRevision(vacancyID uint, revision int) (*models.VacancyRevision, error)
+	FindLatest(vacancyID uint) (*models.VacancyRevision, error)
+	Save(revision *models.VacancyRevision) error
+	WithTx(tx *gorm.DB) VacancyRevisionRepository
+}
+
+// vacancyRevisionRepository реализация VacancyRevisionRepository
+type vacancyRevisionRepository struct {
+	db *gorm.DB
+}
+
+// NewVacancyRevisionRepository создает новый экземпляр репозитория ревизий вакансий
+func NewVacancyRevisionRepository(db *gorm.DB) VacancyRevisionRepository {
+	return &vacancyRevisionRepository{db: db}
+}
+
+// WithTx возвращает репозиторий, работающий в транзакции tx
+func (r *vacancyRevisionRepository) WithTx(tx *gorm.DB) VacancyRevisionRepository {
+	return &vacancyRevisionRepository{db: tx}
+}
+
+// FindByVacancy получает ревизии вакансии, начиная с последней
+func (r *vacancyRevisionRepository) FindByVacancy(vacancyID uint, page int) ([]models.VacancyRevision, int64, error) {
+	var revisions []models.VacancyRevision
+	var total int64
+
+	db := r.db.Model(&models.VacancyRevision{}).Where("vacancy_id = ?", vacancyID)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * PageSize
+
+	if err := db.Order("revision DESC").Limit(PageSize).Offset(offset).Find(&revisions).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return revisions, total, nil
+}
+
+// FindByRevision находит ревизию вакансии по ее номеру
+func (r *vacancyRevisionRepository) FindByRevision(vacancyID uint, revision int) (*models.VacancyRevision, error) {
+	var result models.VacancyRevision
+	if err := r.db.Where("vacancy_id = ? AND revision = ?", vacancyID, revision).First(&result).Error; err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FindLatest находит последнюю ревизию вакансии
+func (r *vacancyRevisionRepository) FindLatest(vacancyID uint) (*models.VacancyRevision, error) {
+	var result models.VacancyRevision
+	if err := r.db.Where("vacancy_id = ?", vacancyID).Order("revision DESC").First(&result).Error; err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Save сохраняет новую ревизию, присваивая ей следующий номер
+// Одновременная запись ревизии с тем же номером отклоняется уникальным индексом
+func (r *vacancyRevisionRepository) Save(revision *models.VacancyRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&models.VacancyRevision{}).
+			Where("vacancy_id = ?", revision.VacancyID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&last).Error; err != nil {
+			return err
+		}
+
+		revision.Revision = last + 1
+		return tx.Create(revision).Error
+	})
+}
@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job периодическая фоновая задача
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler запускает периодические задачи внутри процесса
+// Задачи не хранят состояние в памяти: все, что нужно сделать, определяется по данным в базе,
+// поэтому после перезапуска пропущенная работа выполняется при первом же запуске задачи
+type Scheduler struct {
+	jobs []Job
+}
+
+// New создает новый планировщик
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Add регистрирует задачу
+// Задача с неположительным интервалом не регистрируется: таймер с таким интервалом создать нельзя
+func (s *Scheduler) Add(name string, interval time.Duration, run func(ctx context.Context) error) {
+	if interval <= 0 {
+		log.Printf("Warning: scheduler job %s skipped: interval %s is not positive", name, interval)
+		return
+	}
+	s.jobs = append(s.jobs, Job{Name: name, Interval: interval, Run: run})
+}
+
+// Start запускает все задачи в отдельных горутинах до отмены контекста
+// Каждая задача выполняется сразу при старте, затем с заданным интервалом
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		go s.loop(ctx, job)
+	}
+}
+
+// loop выполняет задачу по таймеру
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run выполняет задачу один раз, не давая панике остановить планировщик
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Scheduler job %s panicked: %v", job.Name, r)
+		}
+	}()
+
+	if err := job.Run(ctx); err != nil {
+		log.Printf("Scheduler job %s failed: %v", job.Name, err)
+	}
+}
@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAddSkipsNonPositiveInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		want     int
+	}{
+		{"positive", time.Minute, 1},
+		{"zero", 0, 0},
+		{"negative", -time.Second, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New()
+			s.Add("job", tt.interval, func(ctx context.Context) error { return nil })
+			if len(s.jobs) != tt.want {
+				t.Errorf("registered %d jobs, want %d", len(s.jobs), tt.want)
+			}
+
+			// Запуск не должен паниковать и для пропущенной задачи
+			ctx, cancel := context.WithCancel(context.Background())
+			s.Start(ctx)
+			cancel()
+		})
+	}
+}
+
+func TestStartRunsJobRepeatedly(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := make(chan struct{}, 3)
+	s := New()
+	s.Add("job", time.Millisecond, func(ctx context.Context) error {
+		select {
+		case runs <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+	s.Start(ctx)
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-runs:
+		case <-time.After(time.Second):
+			t.Fatalf("job ran %d times, want 3", i)
+		}
+	}
+}
+
+func TestRunRecoversFromFailures(t *testing.T) {
+	s := New()
+	tests := []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{"error", func(ctx context.Context) error { return errors.New("failed") }},
+		{"panic", func(ctx context.Context) error { panic("boom") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.run(context.Background(), Job{Name: tt.name, Interval: time.Minute, Run: tt.run})
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"vakansii-back-go/config"
+	"vakansii-back-go/mailer"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+
+	"gorm.io/gorm"
+)
+
+// AccountService интерфейс сервиса подтверждения email и восстановления пароля
+type AccountService interface {
+	SendEmailVerification(user *models.User) (map[string]interface{}, error)
+	VerifyEmail(data map[string]interface{}) (map[string]interface{}, error)
+	RequestPasswordReset(data map[string]interface{}) (map[string]interface{}, error)
+	ResetPassword(data map[string]interface{}) (map[string]interface{}, error)
+}
+
+// accountService реализация AccountService
+type accountService struct {
+	cfg          config.AccountConfig
+	userRepo     repositories.UserRepository
+	tokenRepo    repositories.UserTokenRepository
+	tokenService TokenService
+	mail         mailer.Sender
+}
+
+// NewAccountService создает новый экземпляр сервиса учетных записей
+func NewAccountService(cfg config.AccountConfig, userRepo repositories.UserRepository, tokenRepo repositories.UserTokenRepository, tokenService TokenService, mail mailer.Sender) AccountService {
+	return &accountService{
+		cfg:          cfg,
+		userRepo:     userRepo,
+		tokenRepo:    tokenRepo,
+		tokenService: tokenService,
+		mail:         mail,
+	}
+}
+
+// SendEmailVerification выпускает токен подтверждения email и отправляет письмо
+func (s *accountService) SendEmailVerification(user *models.User) (map[string]interface{}, error) {
+	if user.IsEmailVerified() {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Email уже подтвержден",
+		}, nil
+	}
+
+	ttl := time.Duration(s.cfg.EmailVerificationTTL) * time.Second
+	token, err := s.issueToken(user.ID, models.TokenPurposeEmailVerification, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.mail.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Подтверждение email",
+		Body: fmt.Sprintf("Здравствуйте, %s!\n\nДля подтверждения email перейдите по ссылке:\n%s\n\nСсылка действительна до %s.",
+			user.Username, s.link("/verify-email", token), time.Now().Add(ttl).Format("02.01.2006 15:04")),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Письмо для подтверждения email отправлено",
+	}, nil
+}
+
+// VerifyEmail подтверждает email по одноразовому токену
+func (s *accountService) VerifyEmail(data map[string]interface{}) (map[string]interface{}, error) {
+	plain, _ := data["token"].(string)
+
+	token, err := s.useToken(strings.TrimSpace(plain), models.TokenPurposeEmailVerification)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return invalidTokenResult(), nil
+	}
+
+	user, err := s.userRepo.FindByID(token.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invalidTokenResult(), nil
+		}
+		return nil, err
+	}
+
+	if !user.IsEmailVerified() {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Email успешно подтвержден",
+	}, nil
+}
+
+// RequestPasswordReset отправляет письмо со ссылкой для сброса пароля
+// Ответ не зависит от того, существует ли пользователь, чтобы не раскрывать зарегистрированные адреса
+func (s *accountService) RequestPasswordReset(data map[string]interface{}) (map[string]interface{}, error) {
+	email, _ := data["email"].(string)
+
+	result := map[string]interface{}{
+		"success": true,
+		"message": "Если пользователь с таким email существует, ему отправлено письмо",
+	}
+
+	user, err := s.userRepo.FindByEmail(strings.TrimSpace(email))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return result, nil
+		}
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		return result, nil
+	}
+
+	ttl := time.Duration(s.cfg.PasswordResetTTL) * time.Second
+	token, err := s.issueToken(user.ID, models.TokenPurposePasswordReset, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.mail.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Восстановление пароля",
+		Body: fmt.Sprintf("Здравствуйте, %s!\n\nДля установки нового пароля перейдите по ссылке:\n%s\n\nСсылка действительна до %s. Если вы не запрашивали сброс пароля, просто проигнорируйте это письмо.",
+			user.Username, s.link("/reset-password", token), time.Now().Add(ttl).Format("02.01.2006 15:04")),
+	})
+	if err != nil {
+		// Ошибка доставки не раскрывается клиенту
+		log.Printf("Failed to send password reset email to user %d: %v", user.ID, err)
+	}
+
+	return result, nil
+}
+
+// ResetPassword устанавливает новый пароль по одноразовому токену
+// Все refresh токены пользователя отзываются
+func (s *accountService) ResetPassword(data map[string]interface{}) (map[string]interface{}, error) {
+	plain, _ := data["token"].(string)
+	password, _ := data["password"].(string)
+
+	if utf8.RuneCountInString(password) < MinPasswordLength {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Пароль должен содержать не менее 6 символов",
+		}, nil
+	}
+
+	token, err := s.useToken(strings.TrimSpace(plain), models.TokenPurposePasswordReset)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return invalidTokenResult(), nil
+	}
+
+	user, err := s.userRepo.FindByID(token.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invalidTokenResult(), nil
+		}
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		return invalidTokenResult(), nil
+	}
+
+	if err := user.SetPassword(password); err != nil {
+		return nil, err
+	}
+	if err := user.GenerateAuthKey(); err != nil {
+		return nil, err
+	}
+
+	// Ссылка из письма подтверждает владение адресом
+	if !user.IsEmailVerified() {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenService.RevokeUser(user.ID); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Пароль успешно изменен",
+	}, nil
+}
+
+// issueToken аннулирует прежние токены с тем же назначением и выпускает новый
+func (s *accountService) issueToken(userID uint, purpose string, ttl time.Duration) (string, error) {
+	if err := s.tokenRepo.InvalidateAll(userID, purpose); err != nil {
+		return "", err
+	}
+
+	token, plain, err := models.NewUserToken(userID, purpose, ttl)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.tokenRepo.Save(token); err != nil {
+		return "", err
+	}
+	return plain, nil
+}
+
+// useToken находит действующий токен и помечает его использованным
+// Возвращает nil, если токен неизвестен, просрочен или уже использован
+func (s *accountService) useToken(plain string, purpose string) (*models.UserToken, error) {
+	if plain == "" {
+		return nil, nil
+	}
+
+	token, err := s.tokenRepo.FindByHash(models.HashToken(plain), purpose)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if !token.IsValid() {
+		return nil, nil
+	}
+
+	marked, err := s.tokenRepo.MarkUsed(token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, nil
+	}
+
+	return token, nil
+}
+
+// link формирует ссылку на страницу фронтенда с токеном
+func (s *accountService) link(path string, token string) string {
+	return strings.TrimRight(s.cfg.AppURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+// invalidTokenResult ответ для неверного или просроченного токена
+func invalidTokenResult() map[string]interface{} {
+	return map[string]interface{}{
+		"success": false,
+		"message": "Неверная или устаревшая ссылка",
+	}
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+
+	"gorm.io/gorm"
+)
+
+// MaxCoverLetterLength максимальная длина сопроводительного письма
+const MaxCoverLetterLength = 10000
+
+// ApplicationService интерфейс сервиса откликов на вакансии
+type ApplicationService interface {
+	Apply(vacancyID uint, data map[string]interface{}, user *models.User) (map[string]interface{}, error)
+	Withdraw(id uint, user *models.User) (map[string]interface{}, error)
+	GetVacancyApplications(vacancyID uint, status string, page int, user *models.User) (map[string]interface{}, error)
+	GetUserApplications(user *models.User, page int) (map[string]interface{}, error)
+	ChangeStatus(id uint, data map[string]interface{}, user *models.User) (map[string]interface{}, error)
+}
+
+// applicationService реализация ApplicationService
+type applicationService struct {
+	repo        repositories.ApplicationRepository
+	vacancyRepo repositories.VacancyRepository
+	companyRepo repositories.CompanyRepository
+}
+
+// NewApplicationService создает новый экземпляр сервиса откликов
+func NewApplicationService(repo repositories.ApplicationRepository, vacancyRepo repositories.VacancyRepository, companyRepo repositories.CompanyRepository) ApplicationService {
+	return &applicationService{repo: repo, vacancyRepo: vacancyRepo, companyRepo: companyRepo}
+}
+
+// Apply создает отклик соискателя на вакансию
+// Повторный отклик на ту же вакансию отклоняется с ErrConflict
+func (s *applicationService) Apply(vacancyID uint, data map[string]interface{}, user *models.User) (map[string]interface{}, error) {
+	vacancy, err := s.vacancyRepo.FindByID(vacancyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if !vacancy.IsVisibleAt(time.Now()) {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Вакансия не принимает отклики",
+		}, nil
+	}
+
+	if vacancy.IsOwnedBy(user.ID) {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Нельзя откликнуться на собственную вакансию",
+		}, nil
+	}
+
+	coverLetter, _ := data["cover_letter"].(string)
+	coverLetter = strings.TrimSpace(coverLetter)
+	if utf8.RuneCountInString(coverLetter) > MaxCoverLetterLength {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Сопроводительное письмо не должно превышать 10000 символов",
+		}, nil
+	}
+
+	if _, err := s.repo.FindByVacancyAndUser(vacancy.ID, user.ID); err == nil {
+		return nil, ErrConflict
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	application := &models.Application{
+		VacancyID:   vacancy.ID,
+		UserID:      user.ID,
+		CoverLetter: coverLetter,
+		Status:      models.ApplicationStatusNew,
+	}
+
+	// Уникальный индекс защищает от параллельных повторных откликов
+	if err := s.repo.Save(application); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrConflict
+		}
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"id":      application.ID,
+		"message": "Отклик успешно отправлен",
+	}, nil
+}
+
+// Withdraw отзывает отклик соискателя
+// Отклик, рассмотрение которого завершено, отозвать нельзя
+func (s *applicationService) Withdraw(id uint, user *models.User) (map[string]interface{}, error) {
+	application, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if application.UserID != user.ID {
+		return nil, ErrForbidden
+	}
+
+	if application.IsFinal() {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Рассмотрение отклика уже завершено",
+		}, nil
+	}
+
+	if err := s.repo.Delete(application.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Отклик отозван",
+	}, nil
+}
+
+// GetVacancyApplications получает отклики на вакансию
+// Доступно владельцу вакансии, участникам ее компании, модераторам и администраторам
+func (s *applicationService) GetVacancyApplications(vacancyID uint, status string, page int, user *models.User) (map[string]interface{}, error) {
+	vacancy, err := s.vacancyRepo.FindByID(vacancyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if allowed, err := canManageVacancy(s.companyRepo, user, vacancy); err != nil {
+		return nil, err
+	} else if !allowed {
+		return nil, ErrForbidden
+	}
+
+	if status != "" && !models.IsValidApplicationStatus(status) {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Неизвестный статус отклика",
+		}, nil
+	}
+
+	applications, total, err := s.repo.FindByVacancy(vacancy.ID, status, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginatedResult(applications, total, page), nil
+}
+
+// GetUserApplications получает отклики соискателя
+func (s *applicationService) GetUserApplications(user *models.User, page int) (map[string]interface{}, error) {
+	applications, total, err := s.repo.FindByUser(user.ID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginatedResult(applications, total, page), nil
+}
+
+// ChangeStatus переводит отклик в новый статус
+// Доступно владельцу вакансии, участникам ее компании, модераторам и администраторам
+func (s *applicationService) ChangeStatus(id uint, data map[string]interface{}, user *models.User) (map[string]interface{}, error) {
+	application, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	// Вакансия не загружается, если она перемещена в корзину
+	if application.Vacancy == nil {
+		return nil, ErrNotFound
+	}
+	if allowed, err := canManageVacancy(s.companyRepo, user, application.Vacancy); err != nil {
+		return nil, err
+	} else if !allowed {
+		return nil, ErrForbidden
+	}
+
+	status, _ := data["status"].(string)
+	status = strings.TrimSpace(status)
+	if !models.IsValidApplicationStatus(status) {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Неизвестный статус отклика",
+		}, nil
+	}
+
+	if !application.CanTransitionTo(status) {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Недопустимый переход статуса отклика",
+		}, nil
+	}
+
+	application.Status = status
+	if err := s.repo.Update(application); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"status":  application.Status,
+		"message": "Статус отклика обновлен",
+	}, nil
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+
+	"gorm.io/gorm"
+)
+
+// memoryApplications хранилище откликов в памяти, поддерживает только поиск по ID
+type memoryApplications struct {
+	repositories.ApplicationRepository
+	applications map[uint]*models.Application
+}
+
+func (r *memoryApplications) FindByID(id uint) (*models.Application, error) {
+	if application, ok := r.applications[id]; ok {
+		copied := *application
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestChangeApplicationStatusErrors(t *testing.T) {
+	owner := &models.User{ID: 1}
+	vacancy := &models.Vacancy{ID: 1, UserID: &owner.ID}
+
+	tests := []struct {
+		name string
+		id   uint
+		user *models.User
+		want error
+	}{
+		{"missing application", 3, owner, ErrNotFound},
+		{"vacancy in trash", 2, owner, ErrNotFound},
+		{"foreign vacancy", 1, &models.User{ID: 2}, ErrForbidden},
+	}
+
+	applications := &memoryApplications{applications: map[uint]*models.Application{
+		1: {ID: 1, VacancyID: 1, Vacancy: vacancy},
+		// Удаленная вакансия не загружается вместе с откликом
+		2: {ID: 2, VacancyID: 5},
+	}}
+	service := NewApplicationService(applications, nil, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ChangeStatus(tt.id, map[string]interface{}{"status": "accepted"}, tt.user)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("ChangeStatus() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
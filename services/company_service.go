@@ -0,0 +1,410 @@
+package services
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+
+	"gorm.io/gorm"
+)
+
+// CompanyService интерфейс сервиса компаний
+type CompanyService interface {
+	GetCompanyList(page int) (map[string]interface{}, error)
+	GetCompanyByID(id uint) (*models.Company, error)
+	CreateCompany(data map[string]interface{}, user *models.User) (map[string]interface{}, error)
+	UpdateCompany(id uint, data map[string]interface{}, user *models.User) (map[string]interface{}, error)
+	DeleteCompany(id uint, user *models.User) (map[string]interface{}, error)
+	GetCompanyVacancies(id uint, page int) (map[string]interface{}, error)
+	GetMembers(id uint, user *models.User) (map[string]interface{}, error)
+	AddMember(id uint, data map[string]interface{}, user *models.User) (map[string]interface{}, error)
+	RemoveMember(id uint, memberID uint, user *models.User) (map[string]interface{}, error)
+}
+
+// companyService реализация CompanyService
+type companyService struct {
+	repo        repositories.CompanyRepository
+	vacancyRepo repositories.VacancyRepository
+	userRepo    repositories.UserRepository
+}
+
+// NewCompanyService создает новый экземпляр сервиса компаний
+func NewCompanyService(repo repositories.CompanyRepository, vacancyRepo repositories.VacancyRepository, userRepo repositories.UserRepository) CompanyService {
+	return &companyService{repo: repo, vacancyRepo: vacancyRepo, userRepo: userRepo}
+}
+
+// GetCompanyList получает список компаний с пагинацией
+func (s *companyService) GetCompanyList(page int) (map[string]interface{}, error) {
+	companies, total, err := s.repo.FindAll(page)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginatedResult(companies, total, page), nil
+}
+
+// GetCompanyByID получает компанию по ID
+func (s *companyService) GetCompanyByID(id uint) (*models.Company, error) {
+	company, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return company, nil
+}
+
+// CreateCompany создает компанию, пользователь становится ее владельцем
+func (s *companyService) CreateCompany(data map[string]interface{}, user *models.User) (map[string]interface{}, error) {
+	company := &models.Company{}
+
+	if name, ok := data["name"].(string); !ok || strings.TrimSpace(name) == "" {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Название компании обязательно",
+		}, nil
+	}
+
+	if message := applyCompanyData(company, data, user); message != "" {
+		return map[string]interface{}{
+			"success": false,
+			"message": message,
+		}, nil
+	}
+
+	if err := s.repo.Save(company, user.ID); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"id":      company.ID,
+		"message": "Компания успешно создана",
+	}, nil
+}
+
+// UpdateCompany обновляет компанию
+// Изменять компанию могут ее участники, модераторы и администраторы
+func (s *companyService) UpdateCompany(id uint, data map[string]interface{}, user *models.User) (map[string]interface{}, error) {
+	company, member, err := s.findCompanyAndMember(id, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if member == nil && !user.HasRole(models.RoleAdmin, models.RoleModerator) {
+		return nil, ErrForbidden
+	}
+
+	if message := applyCompanyData(company, data, user); message != "" {
+		return map[string]interface{}{
+			"success": false,
+			"message": message,
+		}, nil
+	}
+
+	if err := s.repo.Update(company); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Компания успешно обновлена",
+	}, nil
+}
+
+// DeleteCompany удаляет компанию
+// Удалить компанию может ее владелец или администратор. Вакансии компании сохраняются без привязки к ней
+func (s *companyService) DeleteCompany(id uint, user *models.User) (map[string]interface{}, error) {
+	company, member, err := s.findCompanyAndMember(id, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsAdmin() && (member == nil || !member.IsOwner()) {
+		return nil, ErrForbidden
+	}
+
+	if err := s.repo.Delete(company.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Компания успешно удалена",
+	}, nil
+}
+
+// GetCompanyVacancies получает вакансии компании
+func (s *companyService) GetCompanyVacancies(id uint, page int) (map[string]interface{}, error) {
+	if _, err := s.repo.FindByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	vacancies, total, err := s.vacancyRepo.FindByCompany(id, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginatedResult(vacancies, total, page), nil
+}
+
+// GetMembers получает участников компании
+// Список доступен участникам компании, модераторам и администраторам
+func (s *companyService) GetMembers(id uint, user *models.User) (map[string]interface{}, error) {
+	_, member, err := s.findCompanyAndMember(id, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if member == nil && !user.HasRole(models.RoleAdmin, models.RoleModerator) {
+		return nil, ErrForbidden
+	}
+
+	members, err := s.repo.FindMembers(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{"data": members}, nil
+}
+
+// AddMember добавляет пользователя в компанию или меняет его роль
+// Доступно владельцам компании и администраторам
+func (s *companyService) AddMember(id uint, data map[string]interface{}, user *models.User) (map[string]interface{}, error) {
+	company, member, err := s.findCompanyAndMember(id, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsAdmin() && (member == nil || !member.IsOwner()) {
+		return nil, ErrForbidden
+	}
+
+	userID, ok := data["user_id"].(float64)
+	if !ok || userID < 1 {
+		return map[string]interface{}{
+			"success": false,
+			"message": "ID пользователя обязателен",
+		}, nil
+	}
+
+	role, _ := data["role"].(string)
+	if role == "" {
+		role = models.CompanyRoleManager
+	}
+	if role != models.CompanyRoleOwner && role != models.CompanyRoleManager {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Недопустимая роль участника",
+		}, nil
+	}
+
+	target, err := s.userRepo.FindByID(uint(userID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return map[string]interface{}{
+				"success": false,
+				"message": "Пользователь не найден",
+			}, nil
+		}
+		return nil, err
+	}
+
+	if !target.IsActive() || !target.HasRole(models.RoleEmployer, models.RoleAdmin) {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Участником компании может быть только работодатель",
+		}, nil
+	}
+
+	existing, err := s.repo.FindMember(company.ID, target.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if existing == nil {
+		existing = &models.CompanyMember{CompanyID: company.ID, UserID: target.ID}
+	} else if existing.IsOwner() && role != models.CompanyRoleOwner {
+		if result, err := s.ensureAnotherOwner(company.ID, target.ID); result != nil || err != nil {
+			return result, err
+		}
+	}
+	existing.Role = role
+
+	if err := s.repo.SaveMember(existing); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Участник компании сохранен",
+	}, nil
+}
+
+// RemoveMember исключает пользователя из компании
+// Доступно владельцам компании и администраторам, участник может выйти из компании сам
+func (s *companyService) RemoveMember(id uint, memberID uint, user *models.User) (map[string]interface{}, error) {
+	company, member, err := s.findCompanyAndMember(id, user)
+	if err != nil {
+		return nil, err
+	}
+
+	isSelf := memberID == user.ID && member != nil
+	if !isSelf && !user.IsAdmin() && (member == nil || !member.IsOwner()) {
+		return nil, ErrForbidden
+	}
+
+	target, err := s.repo.FindMember(company.ID, memberID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if target.IsOwner() {
+		if result, err := s.ensureAnotherOwner(company.ID, memberID); result != nil || err != nil {
+			return result, err
+		}
+	}
+
+	if err := s.repo.DeleteMember(company.ID, memberID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Участник исключен из компании",
+	}, nil
+}
+
+// findCompanyAndMember загружает компанию и членство в ней пользователя
+// Если пользователь не состоит в компании, member равен nil
+func (s *companyService) findCompanyAndMember(id uint, user *models.User) (*models.Company, *models.CompanyMember, error) {
+	company, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	member, err := s.repo.FindMember(company.ID, user.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return company, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	return company, member, nil
+}
+
+// ensureAnotherOwner проверяет, что у компании останется владелец кроме указанного пользователя
+func (s *companyService) ensureAnotherOwner(companyID uint, userID uint) (map[string]interface{}, error) {
+	members, err := s.repo.FindMembers(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range members {
+		if m.UserID != userID && m.IsOwner() {
+			return nil, nil
+		}
+	}
+
+	return map[string]interface{}{
+		"success": false,
+		"message": "У компании должен остаться хотя бы один владелец",
+	}, nil
+}
+
+// applyCompanyData переносит присутствующие в запросе поля в компанию
+// Флаг verified могут менять только модераторы и администраторы
+func applyCompanyData(company *models.Company, data map[string]interface{}, user *models.User) string {
+	if value, ok := data["name"]; ok {
+		name, ok := value.(string)
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || utf8.RuneCountInString(name) > 255 {
+			return "Название компании обязательно и не должно превышать 255 символов"
+		}
+		company.Name = name
+	}
+
+	if value, ok := data["description"]; ok {
+		description, ok := value.(string)
+		if !ok {
+			return "Описание должно быть строкой"
+		}
+		company.Description = description
+	}
+
+	if value, ok := data["website"]; ok {
+		website, ok := value.(string)
+		if !ok || (website != "" && !isHTTPURL(website, 255)) {
+			return "Сайт должен быть корректным http(s) адресом"
+		}
+		company.Website = website
+	}
+
+	if value, ok := data["logo"]; ok {
+		logo, ok := value.(string)
+		if !ok || (logo != "" && !isHTTPURL(logo, 512)) {
+			return "Логотип должен быть корректным http(s) адресом"
+		}
+		company.Logo = logo
+	}
+
+	if value, ok := data["verified"]; ok {
+		if !user.HasRole(models.RoleAdmin, models.RoleModerator) {
+			return "Недостаточно прав для изменения статуса проверки"
+		}
+		verified, ok := value.(bool)
+		if !ok {
+			return "Статус проверки должен быть логическим значением"
+		}
+		company.Verified = verified
+	}
+
+	return ""
+}
+
+// isHTTPURL проверяет, что строка является абсолютным http(s) адресом не длиннее max
+func isHTTPURL(value string, max int) bool {
+	if len(value) > max {
+		return false
+	}
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// canManageVacancy проверяет права пользователя на вакансию с учетом членства в компании
+func canManageVacancy(companyRepo repositories.CompanyRepository, user *models.User, vacancy *models.Vacancy) (bool, error) {
+	if user.CanManageVacancy(vacancy) {
+		return true, nil
+	}
+	if vacancy.CompanyID == nil {
+		return false, nil
+	}
+
+	_, err := companyRepo.FindMember(*vacancy.CompanyID, user.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrForbidden возвращается, когда пользователь не имеет прав на операцию
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotFound возвращается, когда запрошенная сущность не существует
+	ErrNotFound = errors.New("not found")
+	// ErrRevisionNotFound возвращается, когда у существующей вакансии нет ревизии с запрошенным номером
+	ErrRevisionNotFound = fmt.Errorf("revision %w", ErrNotFound)
+	// ErrConflict возвращается, когда операция противоречит текущему состоянию данных
+	ErrConflict = errors.New("conflict")
+	// ErrInvalidTransition возвращается, когда переход в запрошенный статус недопустим
+	ErrInvalidTransition = errors.New("invalid status transition")
+	// ErrPreconditionFailed возвращается, когда версия сущности не совпадает с ожидаемой клиентом
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrLastAdmin возвращается при попытке отозвать роль у последнего администратора
+	ErrLastAdmin = fmt.Errorf("last admin: %w", ErrConflict)
+)
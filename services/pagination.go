@@ -0,0 +1,26 @@
+package services
+
+import (
+	"math"
+	"vakansii-back-go/repositories"
+)
+
+// paginatedResult формирует ответ со списком и данными пагинации при размере страницы по умолчанию
+func paginatedResult(data interface{}, total int64, page int) map[string]interface{} {
+	return pageResult(data, total, page, repositories.PageSize)
+}
+
+// pageResult формирует ответ со списком и данными пагинации
+func pageResult(data interface{}, total int64, page int, pageSize int) map[string]interface{} {
+	pageCount := int(math.Ceil(float64(total) / float64(pageSize)))
+
+	return map[string]interface{}{
+		"data": data,
+		"pagination": map[string]interface{}{
+			"total":     total,
+			"page":      page,
+			"pageSize":  pageSize,
+			"pageCount": pageCount,
+		},
+	}
+}
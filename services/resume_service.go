@@ -0,0 +1,310 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+
+	"gorm.io/gorm"
+)
+
+// ResumeService интерфейс сервиса резюме
+type ResumeService interface {
+	GetResumeList(page int, sortBy string, sortOrder string) (map[string]interface{}, error)
+	GetResumeByID(id uint, fields []string, user *models.User) (interface{}, error)
+	CreateResume(data map[string]interface{}, user *models.User) (map[string]interface{}, error)
+	UpdateResume(id uint, data map[string]interface{}, user *models.User) (map[string]interface{}, error)
+	DeleteResume(id uint, user *models.User) (map[string]interface{}, error)
+	SearchResumes(query string, page int, sortOrder string) (map[string]interface{}, error)
+	GetUserResumes(user *models.User, page int) (map[string]interface{}, error)
+}
+
+// resumeService реализация ResumeService
+type resumeService struct {
+	repo repositories.ResumeRepository
+}
+
+// NewResumeService создает новый экземпляр сервиса резюме
+func NewResumeService(repo repositories.ResumeRepository) ResumeService {
+	return &resumeService{repo: repo}
+}
+
+// GetResumeList получает список резюме с пагинацией
+func (s *resumeService) GetResumeList(page int, sortBy string, sortOrder string) (map[string]interface{}, error) {
+	resumes, total, err := s.repo.FindAll(page, sortBy, sortOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginatedResult(resumes, total, page), nil
+}
+
+// GetResumeByID получает резюме по ID с возможностью выбора полей
+func (s *resumeService) GetResumeByID(id uint, fields []string, user *models.User) (interface{}, error) {
+	resume, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if !user.CanViewResume(resume) {
+		return nil, ErrForbidden
+	}
+
+	// Если поля не указаны, возвращаем все резюме
+	if len(fields) == 0 {
+		return resume, nil
+	}
+
+	// Фильтруем поля
+	result := make(map[string]interface{})
+	result["id"] = resume.ID
+
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		switch field {
+		case "user_id":
+			result["user_id"] = resume.UserID
+		case "title":
+			result["title"] = resume.Title
+		case "summary":
+			result["summary"] = resume.Summary
+		case "experience":
+			result["experience"] = resume.Experience
+		case "education":
+			result["education"] = resume.Education
+		case "skills":
+			result["skills"] = resume.Skills
+		case "desired_salary":
+			result["desired_salary"] = resume.DesiredSalary
+		case "additional_fields":
+			result["additional_fields"] = resume.AdditionalFields
+		case "created_at":
+			result["created_at"] = resume.CreatedAt
+		case "updated_at":
+			result["updated_at"] = resume.UpdatedAt
+		}
+	}
+
+	return result, nil
+}
+
+// CreateResume создает новое резюме пользователя
+func (s *resumeService) CreateResume(data map[string]interface{}, user *models.User) (map[string]interface{}, error) {
+	resume := &models.Resume{UserID: user.ID}
+
+	if title, ok := data["title"].(string); !ok || strings.TrimSpace(title) == "" {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Название резюме обязательно",
+		}, nil
+	}
+
+	if message := applyResumeData(resume, data); message != "" {
+		return map[string]interface{}{
+			"success": false,
+			"message": message,
+		}, nil
+	}
+
+	if err := s.repo.Save(resume); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"id":      resume.ID,
+		"message": "Резюме успешно создано",
+	}, nil
+}
+
+// UpdateResume обновляет существующее резюме
+// Изменять резюме может только его владелец, модератор или администратор
+func (s *resumeService) UpdateResume(id uint, data map[string]interface{}, user *models.User) (map[string]interface{}, error) {
+	resume, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if !user.CanManageResume(resume) {
+		return nil, ErrForbidden
+	}
+
+	if message := applyResumeData(resume, data); message != "" {
+		return map[string]interface{}{
+			"success": false,
+			"message": message,
+		}, nil
+	}
+
+	if err := s.repo.Update(resume); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Резюме успешно обновлено",
+	}, nil
+}
+
+// DeleteResume удаляет резюме
+// Удалять резюме может только его владелец, модератор или администратор
+func (s *resumeService) DeleteResume(id uint, user *models.User) (map[string]interface{}, error) {
+	resume, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if !user.CanManageResume(resume) {
+		return nil, ErrForbidden
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Резюме успешно удалено",
+	}, nil
+}
+
+// SearchResumes выполняет полнотекстовый поиск резюме
+func (s *resumeService) SearchResumes(query string, page int, sortOrder string) (map[string]interface{}, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Поисковый запрос не может быть пустым",
+		}, nil
+	}
+
+	resumes, total, err := s.repo.Search(query, page, sortOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	result := paginatedResult(resumes, total, page)
+	result["query"] = query
+	return result, nil
+}
+
+// GetUserResumes получает резюме пользователя
+func (s *resumeService) GetUserResumes(user *models.User, page int) (map[string]interface{}, error) {
+	resumes, total, err := s.repo.FindByUser(user.ID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginatedResult(resumes, total, page), nil
+}
+
+// applyResumeData переносит присутствующие в запросе поля в резюме
+// Возвращает текст ошибки валидации или пустую строку
+func applyResumeData(resume *models.Resume, data map[string]interface{}) string {
+	if value, ok := data["title"]; ok {
+		title, ok := value.(string)
+		title = strings.TrimSpace(title)
+		if !ok || title == "" || utf8.RuneCountInString(title) > 255 {
+			return "Название резюме обязательно и не должно превышать 255 символов"
+		}
+		resume.Title = title
+	}
+
+	if value, ok := data["summary"]; ok {
+		summary, ok := value.(string)
+		if !ok {
+			return "Описание должно быть строкой"
+		}
+		resume.Summary = summary
+	}
+
+	if value, ok := data["experience"]; ok {
+		experience, ok := objectList(value)
+		if !ok {
+			return "Опыт работы должен быть списком объектов"
+		}
+		resume.Experience = experience
+	}
+
+	if value, ok := data["education"]; ok {
+		education, ok := objectList(value)
+		if !ok {
+			return "Образование должно быть списком объектов"
+		}
+		resume.Education = education
+	}
+
+	if value, ok := data["skills"]; ok {
+		skills, ok := stringList(value)
+		if !ok {
+			return "Навыки должны быть списком строк"
+		}
+		resume.Skills = skills
+	}
+
+	if value, ok := data["desired_salary"]; ok {
+		if value == nil {
+			resume.DesiredSalary = nil
+		} else {
+			salary, ok := value.(float64)
+			if !ok || salary < 0 {
+				return "Желаемая зарплата должна быть неотрицательным числом"
+			}
+			desired := int(salary)
+			resume.DesiredSalary = &desired
+		}
+	}
+
+	if additionalFields, ok := data["additional_fields"].(map[string]interface{}); ok {
+		resume.AdditionalFields = additionalFields
+	}
+
+	return ""
+}
+
+// objectList проверяет, что значение является списком JSON объектов
+func objectList(value interface{}) (models.JSONArray, bool) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	for _, item := range items {
+		if _, ok := item.(map[string]interface{}); !ok {
+			return nil, false
+		}
+	}
+	return items, true
+}
+
+// stringList проверяет, что значение является списком непустых строк
+func stringList(value interface{}) (models.JSONArray, bool) {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	result := make(models.JSONArray, 0, len(items))
+	for _, item := range items {
+		str, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		if str = strings.TrimSpace(str); str != "" {
+			result = append(result, str)
+		}
+	}
+	return result, true
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"errors"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+
+	"gorm.io/gorm"
+)
+
+// RoleService интерфейс сервиса управления ролями
+type RoleService interface {
+	GetRoleList() ([]models.Role, error)
+	GrantRole(userID uint, roleName string) (map[string]interface{}, error)
+	RevokeRole(userID uint, roleName string) (map[string]interface{}, error)
+}
+
+// roleService реализация RoleService
+type roleService struct {
+	repo     repositories.RoleRepository
+	userRepo repositories.UserRepository
+}
+
+// NewRoleService создает новый экземпляр сервиса ролей
+func NewRoleService(repo repositories.RoleRepository, userRepo repositories.UserRepository) RoleService {
+	return &roleService{repo: repo, userRepo: userRepo}
+}
+
+// GetRoleList получает список всех ролей
+func (s *roleService) GetRoleList() ([]models.Role, error) {
+	return s.repo.FindAll()
+}
+
+// GrantRole назначает пользователю роль
+func (s *roleService) GrantRole(userID uint, roleName string) (map[string]interface{}, error) {
+	user, role, result, err := s.findUserAndRole(userID, roleName)
+	if result != nil || err != nil {
+		return result, err
+	}
+
+	if !user.HasRole(role.Name) {
+		if err := s.userRepo.AddRole(user, role); err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Роль успешно назначена",
+	}, nil
+}
+
+// RevokeRole отзывает у пользователя роль
+// Роль администратора нельзя отозвать у последнего администратора
+func (s *roleService) RevokeRole(userID uint, roleName string) (map[string]interface{}, error) {
+	user, role, result, err := s.findUserAndRole(userID, roleName)
+	if result != nil || err != nil {
+		return result, err
+	}
+
+	if user.HasRole(role.Name) {
+		if role.Name == models.RoleAdmin {
+			removed, err := s.userRepo.RemoveRoleIfNotLast(user, role)
+			if err != nil {
+				return nil, err
+			}
+			if !removed {
+				return nil, ErrLastAdmin
+			}
+		} else if err := s.userRepo.RemoveRole(user, role); err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": "Роль успешно отозвана",
+	}, nil
+}
+
+// findUserAndRole загружает пользователя и роль
+// Если один из них не найден, возвращает результат с success: false
+func (s *roleService) findUserAndRole(userID uint, roleName string) (*models.User, *models.Role, map[string]interface{}, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, map[string]interface{}{
+				"success": false,
+				"message": "Пользователь не найден",
+			}, nil
+		}
+		return nil, nil, nil, err
+	}
+
+	role, err := s.repo.FindByName(roleName)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, map[string]interface{}{
+				"success": false,
+				"message": "Роль не найдена",
+			}, nil
+		}
+		return nil, nil, nil, err
+	}
+
+	return user, role, nil, nil
+}
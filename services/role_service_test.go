@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"vakansii-back-go/models"
+
+	"gorm.io/gorm"
+)
+
+// memoryRoles справочник ролей в памяти
+type memoryRoles []models.Role
+
+func (r memoryRoles) FindByName(name string) (*models.Role, error) {
+	for i := range r {
+		if r[i].Name == name {
+			return &r[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r memoryRoles) FindAll() ([]models.Role, error) {
+	return r, nil
+}
+
+// memoryRoleUsers хранилище пользователей в памяти с поддержкой отзыва ролей
+type memoryRoleUsers struct {
+	*memoryUsers
+}
+
+func (r *memoryRoleUsers) RemoveRole(user *models.User, role *models.Role) error {
+	stored := r.users[user.ID]
+	stored.Roles = slices.DeleteFunc(stored.Roles, func(held models.Role) bool { return held.ID == role.ID })
+	return nil
+}
+
+func (r *memoryRoleUsers) RemoveRoleIfNotLast(user *models.User, role *models.Role) (bool, error) {
+	holders := 0
+	for _, stored := range r.users {
+		if stored.HasRole(role.Name) {
+			holders++
+		}
+	}
+	if holders <= 1 {
+		return false, nil
+	}
+	return true, r.RemoveRole(user, role)
+}
+
+func TestRevokeRole(t *testing.T) {
+	roles := memoryRoles{{ID: 1, Name: models.RoleAdmin}, {ID: 2, Name: models.RoleModerator}}
+	admin, moderator := roles[0], roles[1]
+
+	tests := []struct {
+		name      string
+		users     map[uint]*models.User
+		userID    uint
+		role      string
+		want      error
+		wantRoles int // сколько ролей останется у пользователя
+	}{
+		{"last admin", map[uint]*models.User{
+			1: {ID: 1, Roles: []models.Role{admin}},
+		}, 1, models.RoleAdmin, ErrLastAdmin, 1},
+		{"one of admins", map[uint]*models.User{
+			1: {ID: 1, Roles: []models.Role{admin}},
+			2: {ID: 2, Roles: []models.Role{admin, moderator}},
+		}, 2, models.RoleAdmin, nil, 1},
+		{"last moderator", map[uint]*models.User{
+			1: {ID: 1, Roles: []models.Role{admin, moderator}},
+		}, 1, models.RoleModerator, nil, 1},
+		{"role not held", map[uint]*models.User{
+			1: {ID: 1, Roles: []models.Role{moderator}},
+		}, 1, models.RoleAdmin, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &memoryRoleUsers{&memoryUsers{users: tt.users}}
+			service := NewRoleService(roles, users)
+
+			_, err := service.RevokeRole(tt.userID, tt.role)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("RevokeRole() error = %v, want %v", err, tt.want)
+			}
+			if got := len(tt.users[tt.userID].Roles); got != tt.wantRoles {
+				t.Errorf("user has %d roles, want %d", got, tt.wantRoles)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"time"
+	"vakansii-back-go/config"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrInvalidToken возвращается для неверных, просроченных или отозванных токенов
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenReused возвращается при повторном использовании refresh токена
+	ErrTokenReused = errors.New("refresh token reused")
+)
+
+// ClientInfo сведения об устройстве, для которого выпускаются токены
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// TokenPair пара токенов, выдаваемая клиенту
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// TokenService интерфейс сервиса выпуска и проверки токенов
+type TokenService interface {
+	IssueTokens(user *models.User, client ClientInfo) (*TokenPair, error)
+	ParseAccessToken(token string) (uint, error)
+	Refresh(refreshToken string, client ClientInfo) (*TokenPair, error)
+	Revoke(refreshToken string, allDevices bool) error
+	RevokeUser(userID uint) error
+}
+
+// tokenService реализация TokenService
+type tokenService struct {
+	cfg      config.JWTConfig
+	repo     repositories.RefreshTokenRepository
+	userRepo repositories.UserRepository
+}
+
+// NewTokenService создает новый экземпляр сервиса токенов
+func NewTokenService(cfg config.JWTConfig, repo repositories.RefreshTokenRepository, userRepo repositories.UserRepository) TokenService {
+	return &tokenService{cfg: cfg, repo: repo, userRepo: userRepo}
+}
+
+// IssueTokens выпускает новую пару токенов, начиная новое семейство refresh токенов
+func (s *tokenService) IssueTokens(user *models.User, client ClientInfo) (*TokenPair, error) {
+	return s.issue(user.ID, "", client)
+}
+
+// ParseAccessToken проверяет подпись и срок действия JWT и возвращает ID пользователя
+func (s *tokenService) ParseAccessToken(tokenString string) (uint, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, s.keyFunc,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(s.cfg.Issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return uint(userID), nil
+}
+
+// Refresh обменивает refresh токен на новую пару токенов
+// Каждый refresh токен одноразовый. Повторное использование уже обмененного
+// токена считается компрометацией и отзывает все семейство
+func (s *tokenService) Refresh(refreshToken string, client ClientInfo) (*TokenPair, error) {
+	token, err := s.findRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.UsedAt != nil {
+		if err := s.repo.RevokeFamily(token.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrTokenReused
+	}
+
+	if token.RevokedAt != nil || token.IsExpired() {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.FindByID(token.UserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if user == nil || !user.IsActive() {
+		if err := s.repo.RevokeFamily(token.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidToken
+	}
+
+	// Параллельный запрос с тем же токеном мог успеть его использовать
+	marked, err := s.repo.MarkUsed(token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		if err := s.repo.RevokeFamily(token.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrTokenReused
+	}
+
+	return s.issue(user.ID, token.FamilyID, client)
+}
+
+// Revoke отзывает семейство refresh токена или все токены пользователя
+func (s *tokenService) Revoke(refreshToken string, allDevices bool) error {
+	token, err := s.findRefreshToken(refreshToken)
+	if err != nil {
+		return err
+	}
+
+	if allDevices {
+		return s.repo.RevokeAllForUser(token.UserID)
+	}
+	return s.repo.RevokeFamily(token.FamilyID)
+}
+
+// RevokeUser отзывает все refresh токены пользователя
+func (s *tokenService) RevokeUser(userID uint) error {
+	return s.repo.RevokeAllForUser(userID)
+}
+
+// issue выпускает JWT и refresh токен в указанном семействе
+func (s *tokenService) issue(userID uint, familyID string, client ClientInfo) (*TokenPair, error) {
+	now := time.Now()
+	accessTTL := time.Duration(s.cfg.AccessTokenTTL) * time.Second
+
+	jti, err := models.NewTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer:    s.cfg.Issuer,
+		Subject:   strconv.FormatUint(uint64(userID), 10),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(accessTTL)),
+		ID:        jti,
+	})
+	jwtToken.Header["kid"] = s.cfg.KeyID
+
+	accessToken, err := jwtToken.SignedString([]byte(s.cfg.Secret))
+	if err != nil {
+		return nil, err
+	}
+
+	refreshTTL := time.Duration(s.cfg.RefreshTokenTTL) * time.Second
+	refresh, plain, err := models.NewRefreshToken(userID, familyID, refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+	refresh.UserAgent = truncate(client.UserAgent, 255)
+	refresh.IP = client.IP
+
+	if err := s.repo.Save(refresh); err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: plain,
+		TokenType:    "Bearer",
+		ExpiresIn:    s.cfg.AccessTokenTTL,
+	}, nil
+}
+
+// findRefreshToken находит refresh токен по его открытому значению
+func (s *tokenService) findRefreshToken(refreshToken string) (*models.RefreshToken, error) {
+	if refreshToken == "" {
+		return nil, ErrInvalidToken
+	}
+
+	token, err := s.repo.FindByHash(models.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+	return token, nil
+}
+
+// keyFunc выбирает ключ проверки подписи по заголовку kid
+func (s *tokenService) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == s.cfg.KeyID {
+		return []byte(s.cfg.Secret), nil
+	}
+	if secret, ok := s.cfg.PreviousKeys[kid]; ok {
+		return []byte(secret), nil
+	}
+	return nil, ErrInvalidToken
+}
+
+// truncate обрезает строку до указанного количества символов
+func truncate(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) > max {
+		return string(runes[:max])
+	}
+	return value
+}
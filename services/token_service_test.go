@@ -0,0 +1,276 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+	"vakansii-back-go/config"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+// memoryRefreshTokens хранилище refresh токенов в памяти
+type memoryRefreshTokens struct {
+	tokens []*models.RefreshToken
+}
+
+func (r *memoryRefreshTokens) FindByHash(hash string) (*models.RefreshToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == hash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRefreshTokens) Save(token *models.RefreshToken) error {
+	token.ID = uint(len(r.tokens) + 1)
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *memoryRefreshTokens) MarkUsed(id uint) (bool, error) {
+	for _, token := range r.tokens {
+		if token.ID == id && token.UsedAt == nil && token.RevokedAt == nil {
+			now := time.Now()
+			token.UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryRefreshTokens) RevokeFamily(familyID string) error {
+	return r.revoke(func(token *models.RefreshToken) bool { return token.FamilyID == familyID })
+}
+
+func (r *memoryRefreshTokens) RevokeAllForUser(userID uint) error {
+	return r.revoke(func(token *models.RefreshToken) bool { return token.UserID == userID })
+}
+
+func (r *memoryRefreshTokens) revoke(match func(token *models.RefreshToken) bool) error {
+	now := time.Now()
+	for _, token := range r.tokens {
+		if match(token) && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+// memoryUsers хранилище пользователей в памяти, поддерживает только поиск по ID
+type memoryUsers struct {
+	repositories.UserRepository
+	users map[uint]*models.User
+}
+
+func (r *memoryUsers) FindByID(id uint) (*models.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+var testJWTConfig = config.JWTConfig{
+	Issuer:          "vakansii-test",
+	KeyID:           "k2",
+	Secret:          "current-secret",
+	PreviousKeys:    map[string]string{"k1": "previous-secret"},
+	AccessTokenTTL:  900,
+	RefreshTokenTTL: 3600,
+}
+
+// newTestTokenService создает сервис токенов с одним активным пользователем
+func newTestTokenService(cfg config.JWTConfig) (*tokenService, *memoryRefreshTokens, *models.User) {
+	user := &models.User{ID: 42, Status: models.StatusActive}
+	tokens := &memoryRefreshTokens{}
+	service := &tokenService{cfg: cfg, repo: tokens, userRepo: &memoryUsers{users: map[uint]*models.User{user.ID: user}}}
+	return service, tokens, user
+}
+
+// signAccessToken подписывает JWT пользователя ключом secret с заголовком kid
+func signAccessToken(t *testing.T, kid string, secret string, issuer string, expiresAt time.Time) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer:    issuer,
+		Subject:   "42",
+		IssuedAt:  jwt.NewNumericDate(expiresAt.Add(-time.Hour)),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	})
+	token.Header["kid"] = kid
+	signed, err := token.SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	return signed
+}
+
+func TestRefreshRotatesTokens(t *testing.T) {
+	service, tokens, user := newTestTokenService(testJWTConfig)
+
+	first, err := service.IssueTokens(user, ClientInfo{UserAgent: "test", IP: "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("IssueTokens() error = %v", err)
+	}
+	second, err := service.Refresh(first.RefreshToken, ClientInfo{})
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if second.RefreshToken == first.RefreshToken || second.AccessToken == first.AccessToken {
+		t.Error("Refresh() returned the same tokens")
+	}
+	if userID, err := service.ParseAccessToken(second.AccessToken); err != nil || userID != user.ID {
+		t.Errorf("ParseAccessToken() = %d, %v, want %d", userID, err, user.ID)
+	}
+
+	if len(tokens.tokens) != 2 {
+		t.Fatalf("stored %d refresh tokens, want 2", len(tokens.tokens))
+	}
+	if tokens.tokens[0].FamilyID != tokens.tokens[1].FamilyID {
+		t.Error("rotated refresh token belongs to another family")
+	}
+	if tokens.tokens[0].UsedAt == nil || tokens.tokens[1].UsedAt != nil {
+		t.Error("only the exchanged refresh token must be marked used")
+	}
+}
+
+func TestRefreshReuseRevokesFamily(t *testing.T) {
+	service, tokens, user := newTestTokenService(testJWTConfig)
+
+	first, _ := service.IssueTokens(user, ClientInfo{})
+	second, err := service.Refresh(first.RefreshToken, ClientInfo{})
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	other, _ := service.IssueTokens(user, ClientInfo{})
+
+	if _, err := service.Refresh(first.RefreshToken, ClientInfo{}); !errors.Is(err, ErrTokenReused) {
+		t.Fatalf("Refresh() with used token error = %v, want ErrTokenReused", err)
+	}
+	if _, err := service.Refresh(second.RefreshToken, ClientInfo{}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Refresh() with token of revoked family error = %v, want ErrInvalidToken", err)
+	}
+
+	// Вход с другого устройства относится к другому семейству и не отзывается
+	if _, err := service.Refresh(other.RefreshToken, ClientInfo{}); err != nil {
+		t.Errorf("Refresh() for another family error = %v", err)
+	}
+	for _, token := range tokens.tokens[:2] {
+		if token.RevokedAt == nil {
+			t.Errorf("refresh token %d of reused family is not revoked", token.ID)
+		}
+	}
+}
+
+func TestRefreshRejectsInvalidTokens(t *testing.T) {
+	service, tokens, user := newTestTokenService(testJWTConfig)
+
+	expired, _ := service.IssueTokens(user, ClientInfo{})
+	tokens.tokens[0].ExpiresAt = time.Now().Add(-time.Minute)
+
+	revoked, _ := service.IssueTokens(user, ClientInfo{})
+	if err := service.Revoke(revoked.RefreshToken, false); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	deleted, _ := service.IssueTokens(user, ClientInfo{})
+	user.Status = models.StatusDeleted
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"expired", expired.RefreshToken},
+		{"revoked", revoked.RefreshToken},
+		{"deleted user", deleted.RefreshToken},
+		{"unknown", "0123456789abcdef"},
+		{"empty", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Refresh(tt.token, ClientInfo{}); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("Refresh() error = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestParseAccessToken(t *testing.T) {
+	service, _, _ := newTestTokenService(testJWTConfig)
+	valid := time.Now().Add(time.Hour)
+	expired := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"current key", signAccessToken(t, "k2", "current-secret", testJWTConfig.Issuer, valid), false},
+		{"previous key", signAccessToken(t, "k1", "previous-secret", testJWTConfig.Issuer, valid), false},
+		{"expired", signAccessToken(t, "k2", "current-secret", testJWTConfig.Issuer, expired), true},
+		{"unknown kid", signAccessToken(t, "k0", "current-secret", testJWTConfig.Issuer, valid), true},
+		{"kid of another key", signAccessToken(t, "k1", "current-secret", testJWTConfig.Issuer, valid), true},
+		{"wrong secret", signAccessToken(t, "k2", "guessed-secret", testJWTConfig.Issuer, valid), true},
+		{"other issuer", signAccessToken(t, "k2", "current-secret", "someone-else", valid), true},
+		{"malformed", "not.a.token", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, err := service.ParseAccessToken(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Errorf("ParseAccessToken() error = %v, want ErrInvalidToken", err)
+				}
+				return
+			}
+			if err != nil || userID != 42 {
+				t.Errorf("ParseAccessToken() = %d, %v, want 42", userID, err)
+			}
+		})
+	}
+}
+
+func TestParseAccessTokenAfterKeyRotation(t *testing.T) {
+	before := testJWTConfig
+	before.KeyID, before.Secret, before.PreviousKeys = "k1", "previous-secret", nil
+	oldService, _, user := newTestTokenService(before)
+
+	pair, err := oldService.IssueTokens(user, ClientInfo{})
+	if err != nil {
+		t.Fatalf("IssueTokens() error = %v", err)
+	}
+
+	// После смены ключа токены, подписанные прежним ключом, действуют до истечения срока
+	rotated, _, _ := newTestTokenService(testJWTConfig)
+	if userID, err := rotated.ParseAccessToken(pair.AccessToken); err != nil || userID != user.ID {
+		t.Errorf("ParseAccessToken() = %d, %v, want %d", userID, err, user.ID)
+	}
+
+	// Когда прежний ключ убран из конфигурации, его токены отклоняются
+	retired := testJWTConfig
+	retired.PreviousKeys = nil
+	retiredService, _, _ := newTestTokenService(retired)
+	if _, err := retiredService.ParseAccessToken(pair.AccessToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("ParseAccessToken() error = %v, want ErrInvalidToken", err)
+	}
+
+	fresh, _ := rotated.IssueTokens(user, ClientInfo{})
+	parsed, _, err := jwt.NewParser().ParseUnverified(fresh.AccessToken, &jwt.RegisteredClaims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified() error = %v", err)
+	}
+	if kid := parsed.Header["kid"]; kid != "k2" {
+		t.Errorf("kid = %v, want k2", kid)
+	}
+	if subject, _ := parsed.Claims.GetSubject(); subject != strconv.Itoa(int(user.ID)) {
+		t.Errorf("sub = %q, want %d", subject, user.ID)
+	}
+}
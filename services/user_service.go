@@ -0,0 +1,260 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/mail"
+	"slices"
+	"strings"
+	"unicode/utf8"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+
+	"gorm.io/gorm"
+)
+
+// MinPasswordLength минимальная длина пароля
+const MinPasswordLength = 6
+
+// UserService интерфейс сервиса пользователей
+type UserService interface {
+	Register(data map[string]interface{}) (map[string]interface{}, error)
+	Login(data map[string]interface{}, client ClientInfo) (map[string]interface{}, error)
+	Refresh(data map[string]interface{}, client ClientInfo) (map[string]interface{}, error)
+	Logout(data map[string]interface{}) (map[string]interface{}, error)
+	Authenticate(accessToken string) (*models.User, error)
+}
+
+// userService реализация UserService
+type userService struct {
+	repo           repositories.UserRepository
+	roleRepo       repositories.RoleRepository
+	tokenService   TokenService
+	accountService AccountService
+}
+
+// NewUserService создает новый экземпляр сервиса пользователей
+func NewUserService(repo repositories.UserRepository, roleRepo repositories.RoleRepository, tokenService TokenService, accountService AccountService) UserService {
+	return &userService{
+		repo:           repo,
+		roleRepo:       roleRepo,
+		tokenService:   tokenService,
+		accountService: accountService,
+	}
+}
+
+// Register регистрирует нового пользователя
+func (s *userService) Register(data map[string]interface{}) (map[string]interface{}, error) {
+	username, _ := data["username"].(string)
+	email, _ := data["email"].(string)
+	password, _ := data["password"].(string)
+	roleName, _ := data["role"].(string)
+
+	username = strings.TrimSpace(username)
+	email = strings.TrimSpace(email)
+	roleName = strings.TrimSpace(roleName)
+	if roleName == "" {
+		roleName = models.RoleCandidate
+	}
+
+	if username == "" || utf8.RuneCountInString(username) > 255 {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Имя пользователя обязательно и не должно превышать 255 символов",
+		}, nil
+	}
+
+	if _, err := mail.ParseAddress(email); err != nil || len(email) > 255 {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Некорректный email",
+		}, nil
+	}
+
+	if utf8.RuneCountInString(password) < MinPasswordLength {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Пароль должен содержать не менее 6 символов",
+		}, nil
+	}
+
+	// При регистрации можно выбрать только роль соискателя или работодателя
+	if !slices.Contains(models.SelfAssignableRoles, roleName) {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Недопустимая роль",
+		}, nil
+	}
+
+	// Проверяем уникальность имени и email
+	if _, err := s.repo.FindByUsername(username); err == nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Пользователь с таким именем уже существует",
+		}, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if _, err := s.repo.FindByEmail(email); err == nil {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Пользователь с таким email уже существует",
+		}, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	role, err := s.roleRepo.FindByName(roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Username: username,
+		Email:    email,
+		Status:   models.StatusActive,
+		Roles:    []models.Role{*role},
+	}
+
+	if err := user.SetPassword(password); err != nil {
+		return nil, err
+	}
+
+	if err := user.GenerateAuthKey(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Save(user); err != nil {
+		return nil, err
+	}
+
+	// Ошибка отправки письма не отменяет регистрацию, письмо можно запросить повторно
+	if _, err := s.accountService.SendEmailVerification(user); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"id":      user.ID,
+		"message": "Пользователь успешно зарегистрирован",
+	}, nil
+}
+
+// Login проверяет учетные данные и выдает пару токенов: JWT доступа и refresh токен
+func (s *userService) Login(data map[string]interface{}, client ClientInfo) (map[string]interface{}, error) {
+	username, _ := data["username"].(string)
+	password, _ := data["password"].(string)
+	username = strings.TrimSpace(username)
+
+	invalidCredentials := map[string]interface{}{
+		"success": false,
+		"message": "Неверное имя пользователя или пароль",
+	}
+
+	if username == "" || password == "" {
+		return invalidCredentials, nil
+	}
+
+	// Входить можно как по имени пользователя, так и по email
+	user, err := s.repo.FindByUsername(username)
+	if errors.Is(err, gorm.ErrRecordNotFound) && strings.Contains(username, "@") {
+		user, err = s.repo.FindByEmail(username)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invalidCredentials, nil
+		}
+		return nil, err
+	}
+
+	if !user.ValidatePassword(password) {
+		return invalidCredentials, nil
+	}
+
+	if !user.IsActive() {
+		return map[string]interface{}{
+			"success": false,
+			"message": "Учетная запись заблокирована",
+		}, nil
+	}
+
+	tokens, err := s.tokenService.IssueTokens(user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenResult(tokens, user), nil
+}
+
+// Refresh обменивает refresh токен на новую пару токенов
+func (s *userService) Refresh(data map[string]interface{}, client ClientInfo) (map[string]interface{}, error) {
+	refreshToken, _ := data["refresh_token"].(string)
+
+	tokens, err := s.tokenService.Refresh(strings.TrimSpace(refreshToken), client)
+	if err != nil {
+		if errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrTokenReused) {
+			return map[string]interface{}{
+				"success": false,
+				"message": "Неверный или просроченный refresh токен",
+			}, nil
+		}
+		return nil, err
+	}
+
+	return tokenResult(tokens, nil), nil
+}
+
+// Logout отзывает refresh токен (и все токены пользователя при all: true)
+func (s *userService) Logout(data map[string]interface{}) (map[string]interface{}, error) {
+	refreshToken, _ := data["refresh_token"].(string)
+	allDevices, _ := data["all"].(bool)
+
+	err := s.tokenService.Revoke(strings.TrimSpace(refreshToken), allDevices)
+	if err != nil && !errors.Is(err, ErrInvalidToken) {
+		return nil, err
+	}
+
+	// Неизвестный токен не считается ошибкой, чтобы выход был идемпотентным
+	return map[string]interface{}{
+		"success": true,
+		"message": "Выход выполнен",
+	}, nil
+}
+
+// Authenticate проверяет JWT доступа и возвращает активного пользователя
+func (s *userService) Authenticate(accessToken string) (*models.User, error) {
+	userID, err := s.tokenService.ParseAccessToken(accessToken)
+	if err != nil {
+		return nil, nil
+	}
+
+	user, err := s.repo.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if !user.IsActive() {
+		return nil, nil
+	}
+
+	return user, nil
+}
+
+// tokenResult формирует ответ с выданными токенами
+func tokenResult(tokens *TokenPair, user *models.User) map[string]interface{} {
+	result := map[string]interface{}{
+		"success":       true,
+		"access_token":  tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"token_type":    tokens.TokenType,
+		"expires_in":    tokens.ExpiresIn,
+	}
+	if user != nil {
+		result["user"] = user
+	}
+	return result
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"vakansii-back-go/dto"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+)
+
+// vacancyCursor содержимое курсора списка вакансий
+// Курсор привязан к сортировке, с которой он был получен
+type vacancyCursor struct {
+	Sort     string          `json:"s"`
+	Value    json.RawMessage `json:"v"`
+	ID       uint            `json:"id"`
+	Backward bool            `json:"b,omitempty"`
+}
+
+// pageRequest преобразует параметры страницы в запрос к репозиторию, разбирая курсор
+// Размер страницы ограничивается maxPageSize, без ограничения используется размер по умолчанию
+func (s *vacancyService) pageRequest(query dto.PageQuery, sort repositories.VacancySort) (repositories.PageRequest, error) {
+	page := repositories.PageRequest{Number: query.Page, Size: query.PerPage}
+	if page.Size > s.maxPageSize {
+		page.Size = s.maxPageSize
+	}
+	if query.Cursor == nil {
+		return page, nil
+	}
+
+	page.Keyset = true
+	if *query.Cursor == "" {
+		return page, nil
+	}
+
+	invalid := dto.NewValidationError("cursor", "invalid")
+	data, err := base64.RawURLEncoding.DecodeString(*query.Cursor)
+	if err != nil {
+		return page, invalid
+	}
+	var cursor vacancyCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sort.String() || cursor.ID == 0 {
+		return page, invalid
+	}
+	value, err := sort.DecodeKey(cursor.Value)
+	if err != nil {
+		return page, invalid
+	}
+
+	page.After = &repositories.Keyset{Value: value, ID: cursor.ID, Backward: cursor.Backward}
+	return page, nil
+}
+
+// encodeCursor формирует курсор на позицию вакансии
+func encodeCursor(sort repositories.VacancySort, vacancy *models.Vacancy, backward bool) string {
+	value, _ := json.Marshal(sort.KeyOf(vacancy))
+	data, _ := json.Marshal(vacancyCursor{Sort: sort.String(), Value: value, ID: vacancy.ID, Backward: backward})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// cursorPage отбрасывает лишнюю вакансию страницы, выбранной по курсору, и формирует курсоры соседних страниц
+// Отсутствующая соседняя страница обозначается nil
+func cursorPage(vacancies []models.Vacancy, page repositories.PageRequest, sort repositories.VacancySort) ([]models.Vacancy, *string, *string) {
+	backward := page.After != nil && page.After.Backward
+	more := len(vacancies) > page.PageSize()
+	if more {
+		if backward {
+			vacancies = vacancies[len(vacancies)-page.PageSize():]
+		} else {
+			vacancies = vacancies[:page.PageSize()]
+		}
+	}
+	if len(vacancies) == 0 {
+		return vacancies, nil, nil
+	}
+
+	var next, prev *string
+	// Курсор, по которому пришел запрос, указывает на вакансию с другой стороны страницы
+	if more || backward {
+		cursor := encodeCursor(sort, &vacancies[len(vacancies)-1], false)
+		next = &cursor
+	}
+	if (more && backward) || (page.After != nil && !backward) {
+		cursor := encodeCursor(sort, &vacancies[0], true)
+		prev = &cursor
+	}
+	return vacancies, next, prev
+}
+
+// cursorResult формирует ответ со списком и курсорами соседних страниц
+func cursorResult(data interface{}, total int64, pageSize int, next *string, prev *string) map[string]interface{} {
+	return map[string]interface{}{
+		"data": data,
+		"pagination": map[string]interface{}{
+			"total":      total,
+			"pageSize":   pageSize,
+			"nextCursor": next,
+			"prevCursor": prev,
+		},
+	}
+}
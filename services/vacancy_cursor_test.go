@@ -0,0 +1,225 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+	"vakansii-back-go/dto"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+)
+
+// assertFieldError проверяет, что err - ошибка проверки поля field с кодом code
+func assertFieldError(t *testing.T, err error, field string, code string) {
+	t.Helper()
+	var validationErr *dto.ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Errors) != 1 {
+		t.Fatalf("error = %v, want validation error %s/%s", err, field, code)
+	}
+	if got := validationErr.Errors[0]; got.Field != field || got.Code != code {
+		t.Errorf("error = %s/%s, want %s/%s", got.Field, got.Code, field, code)
+	}
+}
+
+// cursorVacancies возвращает вакансии с ID от 1 до n, отсортированные по убыванию даты создания
+func cursorVacancies(n int) []models.Vacancy {
+	start := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	vacancies := make([]models.Vacancy, n)
+	for i := range vacancies {
+		vacancies[i] = models.Vacancy{ID: uint(i + 1), CreatedAt: start.Add(-time.Duration(i) * time.Hour)}
+	}
+	return vacancies
+}
+
+func TestPageRequest(t *testing.T) {
+	service := &vacancyService{maxPageSize: 50}
+	sort := repositories.VacancySort{Column: "created_at", Desc: true}
+
+	page, err := service.pageRequest(dto.PageQuery{Page: 2, PerPage: 500}, sort)
+	if err != nil {
+		t.Fatalf("pageRequest() error = %v", err)
+	}
+	if want := (repositories.PageRequest{Number: 2, Size: 50}); !reflect.DeepEqual(page, want) {
+		t.Errorf("pageRequest() = %+v, want %+v", page, want)
+	}
+
+	empty := ""
+	page, err = service.pageRequest(dto.PageQuery{PerPage: 20, Cursor: &empty}, sort)
+	if err != nil {
+		t.Fatalf("pageRequest() error = %v", err)
+	}
+	if want := (repositories.PageRequest{Size: 20, Keyset: true}); !reflect.DeepEqual(page, want) {
+		t.Errorf("pageRequest() for empty cursor = %+v, want %+v", page, want)
+	}
+}
+
+func TestPageRequestCursorRoundTrip(t *testing.T) {
+	service := &vacancyService{}
+	salaryBase := 1500.25
+	vacancy := &models.Vacancy{
+		ID:               7,
+		CreatedAt:        time.Date(2025, 1, 10, 12, 0, 0, 500000000, time.UTC),
+		SalaryBase:       &salaryBase,
+		AdditionalFields: models.JSONB{"level": 3.0},
+	}
+
+	tests := []struct {
+		name     string
+		sort     repositories.VacancySort
+		backward bool
+		want     interface{}
+	}{
+		{"created_at", repositories.VacancySort{Column: "created_at", Desc: true}, false, vacancy.CreatedAt},
+		{"created_at backward", repositories.VacancySort{Column: "created_at"}, true, vacancy.CreatedAt},
+		{"salary", repositories.VacancySort{Column: "salary"}, false, 1500.25},
+		{"json number", repositories.VacancySort{JSONField: &repositories.AdditionalField{Key: "level", Type: repositories.FieldTypeNumber}}, true, 3.0},
+		{"json missing key", repositories.VacancySort{JSONField: &repositories.AdditionalField{Key: "city", Type: repositories.FieldTypeString}}, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := encodeCursor(tt.sort, vacancy, tt.backward)
+			page, err := service.pageRequest(dto.PageQuery{Cursor: &cursor}, tt.sort)
+			if err != nil {
+				t.Fatalf("pageRequest() error = %v", err)
+			}
+			if !page.Keyset || page.After == nil {
+				t.Fatalf("pageRequest() = %+v, want keyset position", page)
+			}
+			if page.After.ID != vacancy.ID || page.After.Backward != tt.backward {
+				t.Errorf("position = %+v, want ID %d backward %v", page.After, vacancy.ID, tt.backward)
+			}
+			if want, ok := tt.want.(time.Time); ok {
+				if got, ok := page.After.Value.(time.Time); !ok || !got.Equal(want) {
+					t.Errorf("position value = %#v, want %v", page.After.Value, want)
+				}
+				return
+			}
+			if !reflect.DeepEqual(page.After.Value, tt.want) {
+				t.Errorf("position value = %#v, want %#v", page.After.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageRequestRejectsInvalidCursor(t *testing.T) {
+	service := &vacancyService{}
+	sort := repositories.VacancySort{Column: "created_at", Desc: true}
+	vacancy := &cursorVacancies(1)[0]
+	encode := func(data string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(data))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"other sort order", encodeCursor(repositories.VacancySort{Column: "created_at"}, vacancy, false)},
+		{"other sort column", encodeCursor(repositories.VacancySort{Column: "salary", Desc: true}, vacancy, false)},
+		{"not base64", "not a cursor!"},
+		{"not json", encode("created_at:desc")},
+		{"missing id", encode(`{"s":"created_at:desc","v":"2025-01-10T12:00:00Z"}`)},
+		{"wrong value type", encode(`{"s":"created_at:desc","v":42,"id":1}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.pageRequest(dto.PageQuery{Cursor: &tt.cursor}, sort)
+			assertFieldError(t, err, "cursor", "invalid")
+		})
+	}
+}
+
+func TestCursorPage(t *testing.T) {
+	sort := repositories.VacancySort{Column: "created_at", Desc: true}
+	after := &repositories.Keyset{ID: 100}
+	before := &repositories.Keyset{ID: 100, Backward: true}
+
+	tests := []struct {
+		name     string
+		fetched  []models.Vacancy
+		after    *repositories.Keyset
+		wantIDs  []uint
+		wantNext uint // ID вакансии, на которую указывает курсор следующей страницы, 0 - страницы нет
+		wantPrev uint
+	}{
+		{"first page with more", cursorVacancies(4), nil, []uint{1, 2, 3}, 3, 0},
+		{"first page is last", cursorVacancies(3), nil, []uint{1, 2, 3}, 0, 0},
+		{"forward with more", cursorVacancies(4), after, []uint{1, 2, 3}, 3, 1},
+		{"forward last page", cursorVacancies(2), after, []uint{1, 2}, 0, 1},
+		{"backward with more", cursorVacancies(4), before, []uint{2, 3, 4}, 4, 2},
+		{"backward first page", cursorVacancies(2), before, []uint{1, 2}, 2, 0},
+		{"empty", nil, after, nil, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := repositories.PageRequest{Size: 3, Keyset: true, After: tt.after}
+			vacancies, next, prev := cursorPage(tt.fetched, page, sort)
+
+			var ids []uint
+			for _, vacancy := range vacancies {
+				ids = append(ids, vacancy.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("cursorPage() IDs = %v, want %v", ids, tt.wantIDs)
+			}
+
+			checkCursor(t, "next", next, sort, tt.wantNext, false)
+			checkCursor(t, "prev", prev, sort, tt.wantPrev, true)
+		})
+	}
+}
+
+// checkCursor проверяет, что курсор указывает на вакансию wantID в направлении backward
+func checkCursor(t *testing.T, name string, cursor *string, sort repositories.VacancySort, wantID uint, backward bool) {
+	t.Helper()
+	if wantID == 0 {
+		if cursor != nil {
+			t.Errorf("%s cursor = %q, want nil", name, *cursor)
+		}
+		return
+	}
+	if cursor == nil {
+		t.Errorf("%s cursor = nil, want position of %d", name, wantID)
+		return
+	}
+
+	page, err := (&vacancyService{}).pageRequest(dto.PageQuery{Cursor: cursor}, sort)
+	if err != nil {
+		t.Fatalf("%s cursor: pageRequest() error = %v", name, err)
+	}
+	if page.After.ID != wantID || page.After.Backward != backward {
+		t.Errorf("%s cursor position = %+v, want ID %d backward %v", name, page.After, wantID, backward)
+	}
+}
+
+func TestPageRequestAlwaysClampsPageSize(t *testing.T) {
+	sort := repositories.VacancySort{Column: "created_at", Desc: true}
+	tests := []struct {
+		name        string
+		maxPageSize int
+		perPage     int
+		want        int
+	}{
+		{"within limit", 100, 20, 20},
+		{"above limit", 100, 1000000, 100},
+		{"no limit configured", 0, 1000000, repositories.PageSize},
+		{"negative limit", -1, 1000000, repositories.PageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &vacancyService{maxPageSize: tt.maxPageSize}
+			page, err := service.pageRequest(dto.PageQuery{Page: 1, PerPage: tt.perPage}, sort)
+			if err != nil {
+				t.Fatalf("pageRequest() error = %v", err)
+			}
+			if got := page.PageSize(); got != tt.want {
+				t.Errorf("PageSize() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
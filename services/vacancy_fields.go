@@ -0,0 +1,137 @@
+package services
+
+import (
+	"log"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"vakansii-back-go/dto"
+	"vakansii-back-go/repositories"
+)
+
+// fieldKeyPattern допустимые имена ключей additional_fields в списке разрешенных
+var fieldKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// fieldOperators операторы, допустимые для каждого типа ключа
+// Для массивов eq проверяет наличие элемента, in - наличие любого из элементов
+var fieldOperators = map[string][]string{
+	repositories.FieldTypeString: {repositories.FieldOpEq, repositories.FieldOpIn},
+	repositories.FieldTypeNumber: {
+		repositories.FieldOpEq, repositories.FieldOpIn,
+		repositories.FieldOpGt, repositories.FieldOpGte,
+		repositories.FieldOpLt, repositories.FieldOpLte,
+	},
+	repositories.FieldTypeBool:  {repositories.FieldOpEq},
+	repositories.FieldTypeArray: {repositories.FieldOpEq, repositories.FieldOpIn},
+}
+
+// newFilterFields формирует список разрешенных ключей additional_fields из конфигурации
+// Ключи с недопустимым именем или неизвестным типом пропускаются
+func newFilterFields(types map[string]string) map[string]repositories.AdditionalField {
+	fields := make(map[string]repositories.AdditionalField, len(types))
+	for key, fieldType := range types {
+		fieldType = strings.ToLower(strings.TrimSpace(fieldType))
+		if !fieldKeyPattern.MatchString(key) {
+			log.Printf("Skipping additional field filter %q: invalid key", key)
+			continue
+		}
+		if _, ok := fieldOperators[fieldType]; !ok {
+			log.Printf("Skipping additional field filter %q: unknown type %q", key, fieldType)
+			continue
+		}
+		fields[key] = repositories.AdditionalField{Key: key, Type: fieldType}
+	}
+	return fields
+}
+
+// fieldConditions проверяет условия af.<ключ>=[оператор:]значение и преобразует их в условия отбора
+// Несколько условий на один ключ объединяются через И, например af.experience=gte:1&af.experience=lte:3
+func (s *vacancyService) fieldConditions(query map[string][]string) ([]repositories.FieldCondition, error) {
+	var conditions []repositories.FieldCondition
+	for _, key := range slices.Sorted(maps.Keys(query)) {
+		values := query[key]
+		name := dto.AdditionalFieldPrefix + key
+		field, ok := s.fields[key]
+		if !ok {
+			return nil, dto.NewValidationError(name, "not_allowed")
+		}
+
+		for _, value := range values {
+			condition, err := parseFieldCondition(name, field, value)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, condition)
+		}
+	}
+	return conditions, nil
+}
+
+// parseFieldCondition разбирает одно условие на ключ additional_fields
+// Значение без известного оператора целиком сравнивается на равенство
+func parseFieldCondition(name string, field repositories.AdditionalField, value string) (repositories.FieldCondition, error) {
+	condition := repositories.FieldCondition{Field: field, Op: repositories.FieldOpEq}
+	if op, rest, found := strings.Cut(value, ":"); found {
+		// Числовые ключи поддерживают все операторы
+		if slices.Contains(fieldOperators[repositories.FieldTypeNumber], op) {
+			condition.Op, value = op, rest
+		}
+	}
+	if !slices.Contains(fieldOperators[field.Type], condition.Op) {
+		return condition, dto.NewValidationError(name, "operator")
+	}
+
+	raw := []string{strings.TrimSpace(value)}
+	if condition.Op == repositories.FieldOpIn {
+		raw = raw[:0]
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				raw = append(raw, item)
+			}
+		}
+	}
+	if len(raw) == 0 || raw[0] == "" {
+		return condition, dto.NewValidationError(name, "required")
+	}
+
+	for _, item := range raw {
+		switch field.Type {
+		case repositories.FieldTypeNumber:
+			// NaN и бесконечность ParseFloat принимает, но MySQL не может их сравнить
+			number, err := strconv.ParseFloat(item, 64)
+			if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+				return condition, dto.NewValidationError(name, "type.number")
+			}
+			condition.Values = append(condition.Values, number)
+		case repositories.FieldTypeBool:
+			enabled, err := strconv.ParseBool(item)
+			if err != nil {
+				return condition, dto.NewValidationError(name, "type.boolean")
+			}
+			condition.Values = append(condition.Values, enabled)
+		default:
+			condition.Values = append(condition.Values, item)
+		}
+	}
+	return condition, nil
+}
+
+// vacancySort проверяет параметры сортировки списка вакансий
+// sort=af.<ключ> сортирует по разрешенному строковому или числовому ключу additional_fields
+func (s *vacancyService) vacancySort(sortBy string, sortOrder string) (repositories.VacancySort, error) {
+	order := repositories.VacancySort{Column: sortBy, Desc: sortOrder != "asc"}
+
+	key, ok := strings.CutPrefix(sortBy, dto.AdditionalFieldPrefix)
+	if !ok {
+		return order, nil
+	}
+	field, ok := s.fields[key]
+	if !ok || (field.Type != repositories.FieldTypeString && field.Type != repositories.FieldTypeNumber) {
+		return order, dto.NewValidationError("sort", "not_allowed")
+	}
+	order.JSONField = &field
+	return order, nil
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"vakansii-back-go/repositories"
+)
+
+func TestParseFieldCondition(t *testing.T) {
+	number := repositories.AdditionalField{Key: "experience", Type: repositories.FieldTypeNumber}
+	text := repositories.AdditionalField{Key: "city", Type: repositories.FieldTypeString}
+	flag := repositories.AdditionalField{Key: "remote", Type: repositories.FieldTypeBool}
+
+	tests := []struct {
+		name       string
+		field      repositories.AdditionalField
+		value      string
+		wantOp     string
+		wantValues []interface{}
+		wantCode   string // код ошибки проверки, пустой - условие корректно
+	}{
+		{"number equals", number, "3", repositories.FieldOpEq, []interface{}{3.0}, ""},
+		{"number operator", number, "gte:2.5", repositories.FieldOpGte, []interface{}{2.5}, ""},
+		{"number list", number, "in:1, 2,,3", repositories.FieldOpIn, []interface{}{1.0, 2.0, 3.0}, ""},
+		{"number not a number", number, "three", "", nil, "type.number"},
+		{"number NaN", number, "NaN", "", nil, "type.number"},
+		{"number infinity", number, "gt:Inf", "", nil, "type.number"},
+		{"number negative infinity", number, "lt:-Inf", "", nil, "type.number"},
+		{"number NaN in list", number, "in:1,nan", "", nil, "type.number"},
+		{"number overflow", number, "1e400", "", nil, "type.number"},
+		{"number empty", number, "gt:", "", nil, "required"},
+		{"string with colon", text, "Санкт-Петербург: центр", repositories.FieldOpEq, []interface{}{"Санкт-Петербург: центр"}, ""},
+		{"string list", text, "in:Москва,Казань", repositories.FieldOpIn, []interface{}{"Москва", "Казань"}, ""},
+		{"string comparison", text, "gt:a", "", nil, "operator"},
+		{"bool", flag, "true", repositories.FieldOpEq, []interface{}{true}, ""},
+		{"bool invalid", flag, "yes", "", nil, "type.boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := parseFieldCondition("af."+tt.field.Key, tt.field, tt.value)
+			if tt.wantCode != "" {
+				assertFieldError(t, err, "af."+tt.field.Key, tt.wantCode)
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFieldCondition() error = %v", err)
+			}
+			if condition.Op != tt.wantOp || !reflect.DeepEqual(condition.Values, tt.wantValues) {
+				t.Errorf("parseFieldCondition() = %s %#v, want %s %#v", condition.Op, condition.Values, tt.wantOp, tt.wantValues)
+			}
+		})
+	}
+}
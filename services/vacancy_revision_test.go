@@ -0,0 +1,242 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+
+	"gorm.io/gorm"
+)
+
+// memoryVacancies хранилище вакансий в памяти, поддерживает только поиск по ID и обновление с проверкой версии
+type memoryVacancies struct {
+	repositories.VacancyRepository
+	vacancies map[uint]*models.Vacancy
+}
+
+func (r *memoryVacancies) FindByID(id uint) (*models.Vacancy, error) {
+	if vacancy, ok := r.vacancies[id]; ok {
+		copied := *vacancy
+		return &copied, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryVacancies) Update(vacancy *models.Vacancy, inTx repositories.TxFunc) error {
+	stored, ok := r.vacancies[vacancy.ID]
+	if !ok || stored.Version != vacancy.Version {
+		return repositories.ErrVersionConflict
+	}
+	vacancy.Version++
+	if inTx != nil {
+		if err := inTx(nil); err != nil {
+			vacancy.Version--
+			return err
+		}
+	}
+	copied := *vacancy
+	r.vacancies[vacancy.ID] = &copied
+	return nil
+}
+
+// memoryRevisions хранилище ревизий в памяти, транзакции не поддерживаются
+type memoryRevisions struct {
+	revisions []models.VacancyRevision
+}
+
+func (r *memoryRevisions) FindByVacancy(vacancyID uint, page int) ([]models.VacancyRevision, int64, error) {
+	var result []models.VacancyRevision
+	for i := len(r.revisions) - 1; i >= 0; i-- {
+		if r.revisions[i].VacancyID == vacancyID {
+			result = append(result, r.revisions[i])
+		}
+	}
+	return result, int64(len(result)), nil
+}
+
+func (r *memoryRevisions) FindByRevision(vacancyID uint, revision int) (*models.VacancyRevision, error) {
+	for i := range r.revisions {
+		if r.revisions[i].VacancyID == vacancyID && r.revisions[i].Revision == revision {
+			copied := r.revisions[i]
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryRevisions) FindLatest(vacancyID uint) (*models.VacancyRevision, error) {
+	revisions, _, _ := r.FindByVacancy(vacancyID, 1)
+	if len(revisions) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &revisions[0], nil
+}
+
+func (r *memoryRevisions) Save(revision *models.VacancyRevision) error {
+	revision.Revision = 1
+	if latest, err := r.FindLatest(revision.VacancyID); err == nil {
+		revision.Revision = latest.Revision + 1
+	}
+	r.revisions = append(r.revisions, *revision)
+	return nil
+}
+
+func (r *memoryRevisions) WithTx(tx *gorm.DB) repositories.VacancyRevisionRepository {
+	return r
+}
+
+// memoryRates курсы валют в памяти
+type memoryRates map[string]float64
+
+func (r memoryRates) FindByCurrency(currency string) (*models.ExchangeRate, error) {
+	if rate, ok := r[currency]; ok {
+		return &models.ExchangeRate{Currency: currency, Rate: rate}, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// revisionOwner владелец тестовой вакансии
+var revisionOwner = &models.User{ID: 1}
+
+// newRevisionService создает сервис с опубликованной вакансией 1, у которой две ревизии:
+// в первой вакансия была черновиком в долларах, во второй - опубликованной вакансией в рублях
+func newRevisionService(rates memoryRates) (*vacancyService, *memoryVacancies, *memoryRevisions) {
+	salary := 1000
+	draft := models.Vacancy{
+		ID: 1, UserID: &revisionOwner.ID, Title: "Old", Description: "Old text", Language: "ru",
+		SalaryFrom: &salary, Currency: "USD", Status: models.VacancyStatusDraft, Version: 1,
+	}
+	current := draft
+	current.Title = "New"
+	current.Currency = models.BaseCurrency
+	current.Status = models.VacancyStatusPublished
+	current.Version = 2
+
+	vacancies := &memoryVacancies{vacancies: map[uint]*models.Vacancy{1: &current}}
+	revisions := &memoryRevisions{}
+	revisions.Save(&models.VacancyRevision{VacancyID: 1, UserID: &revisionOwner.ID, Snapshot: draft.Snapshot()})
+	revisions.Save(&models.VacancyRevision{VacancyID: 1, UserID: &revisionOwner.ID, Snapshot: current.Snapshot()})
+
+	service := &vacancyService{repo: vacancies, revisionRepo: revisions, rateRepo: rates}
+	return service, vacancies, revisions
+}
+
+func TestRevertVacancy(t *testing.T) {
+	service, vacancies, revisions := newRevisionService(memoryRates{"RUB": 1, "USD": 90})
+
+	result, err := service.RevertVacancy(1, 1, revisionOwner)
+	if err != nil {
+		t.Fatalf("RevertVacancy() error = %v", err)
+	}
+	if result["revision"] != 3 || result["version"] != 3 {
+		t.Errorf("RevertVacancy() = %v, want revision 3 and version 3", result)
+	}
+
+	vacancy := vacancies.vacancies[1]
+	if vacancy.Title != "Old" || vacancy.Currency != "USD" {
+		t.Errorf("vacancy = %s/%s, want Old/USD", vacancy.Title, vacancy.Currency)
+	}
+	// Откат не меняет статус вакансии
+	if vacancy.Status != models.VacancyStatusPublished {
+		t.Errorf("status = %s, want %s", vacancy.Status, models.VacancyStatusPublished)
+	}
+	if latest, _ := revisions.FindLatest(1); !reflect.DeepEqual(latest.Snapshot, vacancy.Snapshot()) {
+		t.Errorf("latest revision = %+v, want current state %+v", latest.Snapshot, vacancy.Snapshot())
+	}
+}
+
+func TestRevertVacancyErrors(t *testing.T) {
+	stranger := &models.User{ID: 2}
+
+	tests := []struct {
+		name     string
+		id       uint
+		revision int
+		user     *models.User
+		want     error
+	}{
+		{"missing vacancy", 2, 1, revisionOwner, ErrNotFound},
+		{"missing revision", 1, 5, revisionOwner, ErrRevisionNotFound},
+		{"foreign vacancy", 1, 1, stranger, ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, vacancies, revisions := newRevisionService(memoryRates{"RUB": 1, "USD": 90})
+			_, err := service.RevertVacancy(tt.id, tt.revision, tt.user)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("RevertVacancy() error = %v, want %v", err, tt.want)
+			}
+			// Отсутствие вакансии не должно выглядеть как отсутствие ревизии
+			if tt.want == ErrNotFound && errors.Is(err, ErrRevisionNotFound) {
+				t.Errorf("RevertVacancy() error = %v, want vacancy not found", err)
+			}
+			if vacancies.vacancies[1].Version != 2 || len(revisions.revisions) != 2 {
+				t.Errorf("vacancy changed after failed revert")
+			}
+		})
+	}
+}
+
+func TestRevertVacancyRechecksCurrency(t *testing.T) {
+	// Курс доллара удален после ревизии 1
+	service, vacancies, revisions := newRevisionService(memoryRates{"RUB": 1})
+
+	_, err := service.RevertVacancy(1, 1, revisionOwner)
+	assertFieldError(t, err, "currency", "not_supported")
+	if vacancies.vacancies[1].Version != 2 || len(revisions.revisions) != 2 {
+		t.Errorf("vacancy changed after failed revert")
+	}
+}
+
+func TestDiffRevisions(t *testing.T) {
+	service, _, _ := newRevisionService(memoryRates{"RUB": 1, "USD": 90})
+
+	tests := []struct {
+		name       string
+		from, to   int
+		wantFields []string
+	}{
+		{"explicit revisions", 1, 2, []string{"title", "currency", "status"}},
+		{"with latest", 1, 0, []string{"title", "currency", "status"}},
+		{"same revision", 2, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.DiffRevisions(1, tt.from, tt.to, revisionOwner)
+			if err != nil {
+				t.Fatalf("DiffRevisions() error = %v", err)
+			}
+			var fields []string
+			for _, change := range result["changes"].([]map[string]interface{}) {
+				fields = append(fields, change["field"].(string))
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("changed fields = %v, want %v", fields, tt.wantFields)
+			}
+		})
+	}
+
+	if _, err := service.DiffRevisions(1, 1, 7, revisionOwner); !errors.Is(err, ErrRevisionNotFound) {
+		t.Errorf("DiffRevisions() with missing revision error = %v, want %v", err, ErrRevisionNotFound)
+	}
+}
+
+func TestChangeStatusRecordsRevision(t *testing.T) {
+	service, vacancies, revisions := newRevisionService(memoryRates{"RUB": 1})
+
+	if _, err := service.ChangeStatus(1, models.VacancyStatusPaused, revisionOwner); err != nil {
+		t.Fatalf("ChangeStatus() error = %v", err)
+	}
+
+	latest, _ := revisions.FindLatest(1)
+	if latest.Revision != 3 || latest.Snapshot.Status != models.VacancyStatusPaused {
+		t.Errorf("latest revision = %d with status %q, want 3 with %q", latest.Revision, latest.Snapshot.Status, models.VacancyStatusPaused)
+	}
+	if vacancies.vacancies[1].Version != 3 {
+		t.Errorf("version = %d, want 3", vacancies.vacancies[1].Version)
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"testing"
+	"vakansii-back-go/dto"
+	"vakansii-back-go/models"
+)
+
+// salaryRates курсы валют для тестов зарплаты
+var salaryRates = memoryRates{"RUB": 1, "USD": 90, "EUR": 100}
+
+func TestVacancyFilterConvertsSalary(t *testing.T) {
+	service := &vacancyService{rateRepo: salaryRates}
+
+	tests := []struct {
+		name    string
+		query   dto.VacancyFilterQuery
+		wantMin *float64
+		wantMax *float64
+	}{
+		{"base currency by default", dto.VacancyFilterQuery{SalaryMin: "100000"}, floatPtr(100000), nil},
+		{"lower case currency", dto.VacancyFilterQuery{SalaryMin: "1000", SalaryMax: "3000", Currency: "usd"}, floatPtr(90000), floatPtr(270000)},
+		{"only maximum", dto.VacancyFilterQuery{SalaryMax: "2000", Currency: "EUR"}, nil, floatPtr(200000)},
+		{"currency without salary", dto.VacancyFilterQuery{Currency: "XXX"}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := service.vacancyFilter(&tt.query)
+			if err != nil {
+				t.Fatalf("vacancyFilter() error = %v", err)
+			}
+			checkSalaryBound(t, "SalaryMin", filter.SalaryMin, tt.wantMin)
+			checkSalaryBound(t, "SalaryMax", filter.SalaryMax, tt.wantMax)
+		})
+	}
+}
+
+func TestVacancyFilterRejectsSalary(t *testing.T) {
+	service := &vacancyService{rateRepo: salaryRates}
+
+	tests := []struct {
+		name      string
+		query     dto.VacancyFilterQuery
+		wantField string
+		wantCode  string
+	}{
+		{"unknown currency", dto.VacancyFilterQuery{SalaryMin: "1000", Currency: "GBP"}, "currency", "not_supported"},
+		{"inverted range", dto.VacancyFilterQuery{SalaryMin: "3000", SalaryMax: "1000"}, "salary_max", "range"},
+		{"not a number", dto.VacancyFilterQuery{SalaryMin: "-5"}, "salary_min", "number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.vacancyFilter(&tt.query)
+			assertFieldError(t, err, tt.wantField, tt.wantCode)
+		})
+	}
+}
+
+func TestCheckSalary(t *testing.T) {
+	service := &vacancyService{rateRepo: salaryRates}
+	rub := models.VacancySnapshot{Currency: "RUB"}
+	gbp := models.VacancySnapshot{Currency: "GBP"}
+
+	tests := []struct {
+		name      string
+		from, to  *int
+		currency  string
+		before    *models.VacancySnapshot
+		wantField string
+		wantCode  string
+	}{
+		{"range", intPtr(100), intPtr(200), "RUB", nil, "", ""},
+		{"only lower bound", intPtr(100), nil, "USD", nil, "", ""},
+		{"only upper bound", nil, intPtr(200), "EUR", &rub, "", ""},
+		{"equal bounds", intPtr(100), intPtr(100), "RUB", nil, "", ""},
+		{"no bounds", nil, nil, "RUB", nil, "salary_from", "required"},
+		{"inverted range", intPtr(200), intPtr(100), "RUB", nil, "salary_to", "range"},
+		{"unknown currency", intPtr(100), nil, "GBP", nil, "currency", "not_supported"},
+		{"changed to unknown currency", intPtr(100), nil, "GBP", &rub, "currency", "not_supported"},
+		{"unchanged currency is not rechecked", intPtr(100), nil, "GBP", &gbp, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vacancy := &models.Vacancy{SalaryFrom: tt.from, SalaryTo: tt.to, Currency: tt.currency}
+			err := service.checkSalary(vacancy, tt.before)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Errorf("checkSalary() error = %v", err)
+				}
+				return
+			}
+			assertFieldError(t, err, tt.wantField, tt.wantCode)
+		})
+	}
+}
+
+// checkSalaryBound сравнивает границу зарплаты фильтра с ожидаемой
+func checkSalaryBound(t *testing.T, name string, got *float64, want *float64) {
+	t.Helper()
+	if (got == nil) != (want == nil) || (got != nil && *got != *want) {
+		t.Errorf("%s = %v, want %v", name, formatBound(got), formatBound(want))
+	}
+}
+
+func formatBound(value *float64) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
@@ -1,241 +1,1126 @@
 package services
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"math"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"vakansii-back-go/config"
+	"vakansii-back-go/dto"
+	"vakansii-back-go/i18n"
 	"vakansii-back-go/models"
+	"vakansii-back-go/patch"
 	"vakansii-back-go/repositories"
 
 	"gorm.io/gorm"
 )
 
+const (
+	// PatchTypeMerge тип содержимого JSON Merge Patch (RFC 7386)
+	PatchTypeMerge = "application/merge-patch+json"
+	// PatchTypeJSON тип содержимого JSON Patch (RFC 6902)
+	PatchTypeJSON = "application/json-patch+json"
+)
+
 // VacancyService интерфейс сервиса вакансий
 type VacancyService interface {
-	GetVacancyList(page int, sortBy string, sortOrder string) (map[string]interface{}, error)
-	GetVacancyByID(id uint, fields []string) (interface{}, error)
-	CreateVacancy(data map[string]interface{}) (map[string]interface{}, error)
-	UpdateVacancy(id uint, data map[string]interface{}) (map[string]interface{}, error)
-	DeleteVacancy(id uint) (map[string]interface{}, error)
-	SearchVacancies(query string, page int, sortOrder string) (map[string]interface{}, error)
+	GetVacancyList(filter *dto.VacancyFilterQuery, page dto.PageQuery, sortBy string, sortOrder string, fields []string, languages []string) (map[string]interface{}, error)
+	GetVacancyByID(id uint, fields []string, languages []string, user *models.User) (interface{}, *models.Vacancy, error)
+	CreateVacancy(request *dto.CreateVacancyRequest, user *models.User) (*dto.VacancyCreatedResponse, error)
+	UpdateVacancy(id uint, request *dto.UpdateVacancyRequest, user *models.User, ifMatch []int) (*dto.VacancyUpdatedResponse, error)
+	PatchVacancy(id uint, contentType string, body []byte, user *models.User, ifMatch []int) (*dto.VacancyUpdatedResponse, error)
+	DeleteVacancy(id uint, user *models.User, ifMatch []int) (map[string]interface{}, error)
+	SearchVacancies(query string, filter *dto.VacancyFilterQuery, page dto.PageQuery, sortOrder string, fields []string, languages []string) (map[string]interface{}, error)
+	ListModifiedAt() (time.Time, error)
+	GetUserVacancies(user *models.User, page int) (map[string]interface{}, error)
+	ChangeStatus(id uint, status string, user *models.User) (map[string]interface{}, error)
+	ApplySchedule(now time.Time) (published int64, expired int64, err error)
+	GetTrash(user *models.User, page int) (map[string]interface{}, error)
+	RestoreVacancy(id uint, user *models.User) (map[string]interface{}, error)
+	PurgeDeleted(before time.Time) (int64, error)
+	GetRevisions(id uint, user *models.User, page int) (map[string]interface{}, error)
+	GetRevision(id uint, revision int, user *models.User) (*models.VacancyRevision, error)
+	DiffRevisions(id uint, from int, to int, user *models.User) (map[string]interface{}, error)
+	RevertVacancy(id uint, revision int, user *models.User) (map[string]interface{}, error)
 }
 
 // vacancyService реализация VacancyService
 type vacancyService struct {
-	repo repositories.VacancyRepository
+	repo         repositories.VacancyRepository
+	companyRepo  repositories.CompanyRepository
+	revisionRepo repositories.VacancyRevisionRepository
+	rateRepo     repositories.ExchangeRateRepository
+	fields       map[string]repositories.AdditionalField // ключи additional_fields для фильтров и сортировки
+	maxPageSize  int
 }
 
 // NewVacancyService создает новый экземпляр сервиса вакансий
-func NewVacancyService(repo repositories.VacancyRepository) VacancyService {
-	return &vacancyService{repo: repo}
+func NewVacancyService(cfg config.VacancyConfig, repo repositories.VacancyRepository, companyRepo repositories.CompanyRepository, revisionRepo repositories.VacancyRevisionRepository, rateRepo repositories.ExchangeRateRepository) VacancyService {
+	return &vacancyService{
+		repo:         repo,
+		companyRepo:  companyRepo,
+		revisionRepo: revisionRepo,
+		rateRepo:     rateRepo,
+		fields:       newFilterFields(cfg.FilterFields),
+		maxPageSize:  cfg.MaxPageSize,
+	}
 }
 
-// GetVacancyList получает список вакансий с пагинацией
-func (s *vacancyService) GetVacancyList(page int, sortBy string, sortOrder string) (map[string]interface{}, error) {
-	vacancies, total, err := s.repo.FindAll(page, sortBy, sortOrder)
+// GetVacancyList получает список вакансий, подходящих под фильтр, с пагинацией по номеру страницы или по курсору
+// Если указаны fields, из базы читаются и возвращаются только эти поля.
+// Название и описание возвращаются на первом подходящем языке из languages
+func (s *vacancyService) GetVacancyList(filter *dto.VacancyFilterQuery, page dto.PageQuery, sortBy string, sortOrder string, fields []string, languages []string) (map[string]interface{}, error) {
+	conditions, err := s.vacancyFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.vacancySort(sortBy, sortOrder)
+	if err != nil {
+		return nil, err
+	}
+	request, err := s.pageRequest(page, order)
 	if err != nil {
 		return nil, err
 	}
 
-	pageCount := int(math.Ceil(float64(total) / float64(repositories.PageSize)))
+	fields = listFields(fields)
+	vacancies, total, err := s.repo.FindAll(conditions, order, request, listColumns(fields))
+	if err != nil {
+		return nil, err
+	}
 
-	return map[string]interface{}{
-		"data": vacancies,
-		"pagination": map[string]interface{}{
-			"total":     total,
-			"page":      page,
-			"pageSize":  repositories.PageSize,
-			"pageCount": pageCount,
-		},
-	}, nil
+	return listResult(vacancies, total, page, request, order, fields, languages), nil
 }
 
 // GetVacancyByID получает вакансию по ID с возможностью выбора полей
-func (s *vacancyService) GetVacancyByID(id uint, fields []string) (interface{}, error) {
+// Неопубликованные, запланированные и просроченные вакансии видны только тем, кто может ими управлять (user может быть nil)
+// Вторым значением возвращается сама вакансия для заголовков ETag и Last-Modified.
+// Название и описание возвращаются на первом подходящем языке из languages, остальные языки - в translations
+func (s *vacancyService) GetVacancyByID(id uint, fields []string, languages []string, user *models.User) (interface{}, *models.Vacancy, error) {
 	vacancy, err := s.repo.FindByID(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, nil
+			return nil, nil, nil
 		}
-		return nil, err
+		return nil, nil, err
 	}
 
+	if !vacancy.IsVisibleAt(time.Now()) {
+		if user == nil {
+			return nil, nil, nil
+		}
+		if allowed, err := canManageVacancy(s.companyRepo, user, vacancy); err != nil {
+			return nil, nil, err
+		} else if !allowed {
+			return nil, nil, nil
+		}
+	}
+
+	vacancy.Localize(languages)
+
 	// Если поля не указаны, возвращаем всю вакансию
 	if len(fields) == 0 {
-		return vacancy, nil
+		return vacancy, vacancy, nil
 	}
 
-	// Фильтруем поля
-	result := make(map[string]interface{})
-	result["id"] = vacancy.ID
+	return projectVacancy(vacancy, fields), vacancy, nil
+}
 
-	for _, field := range fields {
-		field = strings.TrimSpace(field)
-		switch field {
-		case "title":
-			result["title"] = vacancy.Title
-		case "description":
-			result["description"] = vacancy.Description
-		case "salary":
-			result["salary"] = vacancy.Salary
-		case "additional_fields":
-			result["additional_fields"] = vacancy.AdditionalFields
-		case "created_at":
-			result["created_at"] = vacancy.CreatedAt
-		case "updated_at":
-			result["updated_at"] = vacancy.UpdatedAt
+// ListModifiedAt возвращает время последнего изменения публичных списков вакансий для Last-Modified
+func (s *vacancyService) ListModifiedAt() (time.Time, error) {
+	return s.repo.LastModified(time.Now())
+}
+
+// CreateVacancy создает новую вакансию от имени пользователя
+// По умолчанию вакансия создается черновиком, сразу опубликовать ее можно через status: "published"
+func (s *vacancyService) CreateVacancy(request *dto.CreateVacancyRequest, user *models.User) (*dto.VacancyCreatedResponse, error) {
+	request.Title = strings.TrimSpace(request.Title)
+	request.Description = strings.TrimSpace(request.Description)
+	request.Language = strings.ToLower(strings.TrimSpace(request.Language))
+	request.Translations = request.Translations.Normalize()
+	request.Currency = strings.ToUpper(strings.TrimSpace(request.Currency))
+	if err := dto.Validate(request); err != nil {
+		return nil, err
+	}
+
+	vacancy := &models.Vacancy{
+		UserID:           &user.ID,
+		Title:            request.Title,
+		Description:      request.Description,
+		SalaryFrom:       request.SalaryFrom,
+		SalaryTo:         request.SalaryTo,
+		Currency:         request.Currency,
+		SalaryGross:      request.SalaryGross,
+		AdditionalFields: request.AdditionalFields,
+		Status:           models.VacancyStatusDraft,
+	}
+	if vacancy.Currency == "" {
+		vacancy.Currency = models.BaseCurrency
+	}
+	if err := s.checkSalary(vacancy, nil); err != nil {
+		return nil, err
+	}
+	if request.Status != "" {
+		vacancy.Status = request.Status
+	}
+
+	language := request.Language
+	if language == "" {
+		language = i18n.DefaultLanguage()
+	}
+	if err := applyLanguage(vacancy, language, request.Translations.Texts()); err != nil {
+		return nil, err
+	}
+
+	// Компания (опционально)
+	if request.CompanyID != nil {
+		if err := s.applyCompany(vacancy, request.CompanyID, user); err != nil {
+			return nil, err
 		}
 	}
 
-	return result, nil
+	// Расписание публикации (опционально)
+	err := applySchedule(vacancy, dto.NullableOf(request.PublishAt), dto.NullableOf(request.ExpiresAt), request.ExpiresInDays)
+	if err != nil {
+		return nil, err
+	}
+
+	// Вакансия с будущей датой публикации ждет планировщика в статусе черновика
+	if vacancy.IsPublished() && vacancy.PublishAt != nil && vacancy.PublishAt.After(time.Now()) {
+		vacancy.Status = models.VacancyStatusDraft
+	}
+
+	// Сохраняем в базу вместе с первой ревизией
+	err = s.repo.Save(vacancy, func(tx *gorm.DB) error {
+		_, err := s.recordRevision(s.revisionRepo.WithTx(tx), vacancy, vacancy.Snapshot(), user)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.VacancyCreatedResponse{
+		Success: true,
+		ID:      vacancy.ID,
+		Status:  vacancy.Status,
+		Version: vacancy.Version,
+		Message: i18n.NewMessage("vacancy.created", nil),
+	}, nil
 }
 
-// CreateVacancy создает новую вакансию
-func (s *vacancyService) CreateVacancy(data map[string]interface{}) (map[string]interface{}, error) {
-	vacancy := &models.Vacancy{}
+// UpdateVacancy обновляет существующую вакансию и сохраняет ревизию
+// Изменять вакансию может только ее владелец или администратор
+// Если передан ifMatch, версия вакансии должна совпадать с одной из указанных
+func (s *vacancyService) UpdateVacancy(id uint, request *dto.UpdateVacancyRequest, user *models.User, ifMatch []int) (*dto.VacancyUpdatedResponse, error) {
+	if request.Title != nil {
+		*request.Title = strings.TrimSpace(*request.Title)
+	}
+	if request.Description != nil {
+		*request.Description = strings.TrimSpace(*request.Description)
+	}
+	if request.Language != nil {
+		*request.Language = strings.ToLower(strings.TrimSpace(*request.Language))
+	}
+	if request.Translations != nil {
+		*request.Translations = request.Translations.Normalize()
+	}
+	if request.Currency != nil {
+		*request.Currency = strings.ToUpper(strings.TrimSpace(*request.Currency))
+	}
+	if err := dto.Validate(request); err != nil {
+		return nil, err
+	}
 
-	// Заполняем основные поля
-	if title, ok := data["title"].(string); ok {
-		vacancy.Title = title
-	} else {
-		return map[string]interface{}{
-			"success": false,
-			"message": "Название вакансии обязательно",
-		}, nil
+	vacancy, err := s.findManagedVacancy(id, user)
+	if err != nil {
+		return nil, err
 	}
 
-	if description, ok := data["description"].(string); ok {
-		vacancy.Description = description
-	} else {
-		return map[string]interface{}{
-			"success": false,
-			"message": "Описание вакансии обязательно",
-		}, nil
+	if !versionMatches(ifMatch, vacancy.Version) {
+		return nil, ErrPreconditionFailed
 	}
 
-	if salary, ok := data["salary"].(float64); ok {
-		vacancy.Salary = int(salary)
-	} else if salaryInt, ok := data["salary"].(int); ok {
-		vacancy.Salary = salaryInt
-	} else {
-		return map[string]interface{}{
-			"success": false,
-			"message": "Зарплата обязательна",
-		}, nil
+	before := vacancy.Snapshot()
+
+	// Обновляем поля если они присутствуют
+	if request.Title != nil {
+		vacancy.Title = *request.Title
+	}
+	if request.Description != nil {
+		vacancy.Description = *request.Description
+	}
+	if request.SalaryFrom.Set {
+		vacancy.SalaryFrom = request.SalaryFrom.Value
+	}
+	if request.SalaryTo.Set {
+		vacancy.SalaryTo = request.SalaryTo.Value
+	}
+	if request.Currency != nil {
+		vacancy.Currency = *request.Currency
+	}
+	if request.SalaryGross.Set {
+		vacancy.SalaryGross = request.SalaryGross.Value
+	}
+	if err := s.checkSalary(vacancy, &before); err != nil {
+		return nil, err
+	}
+	if request.Language != nil || request.Translations != nil {
+		language := vacancy.Language
+		if request.Language != nil {
+			language = *request.Language
+		}
+		texts := vacancy.Translations.Map()
+		if request.Translations != nil {
+			texts = request.Translations.Texts()
+		}
+		if err := applyLanguage(vacancy, language, texts); err != nil {
+			return nil, err
+		}
+	}
+	if request.AdditionalFields.Set {
+		vacancy.AdditionalFields = nil
+		if request.AdditionalFields.Value != nil {
+			vacancy.AdditionalFields = *request.AdditionalFields.Value
+		}
 	}
 
-	// Дополнительные поля (опционально)
-	if additionalFields, ok := data["additional_fields"].(map[string]interface{}); ok {
-		vacancy.AdditionalFields = additionalFields
+	if request.CompanyID.Set {
+		if err := s.applyCompany(vacancy, request.CompanyID.Value, user); err != nil {
+			return nil, err
+		}
 	}
 
-	// Сохраняем в базу
-	if err := s.repo.Save(vacancy); err != nil {
-		return map[string]interface{}{
-			"success": false,
-			"message": "Ошибка при создании вакансии",
-			"error":   err.Error(),
-		}, nil
+	if err := applySchedule(vacancy, request.PublishAt, request.ExpiresAt, request.ExpiresInDays); err != nil {
+		return nil, err
 	}
 
-	return map[string]interface{}{
-		"success": true,
-		"id":      vacancy.ID,
-		"message": "Вакансия успешно создана",
+	return s.saveVacancy(vacancy, before, user, ifMatch)
+}
+
+// PatchVacancy частично изменяет вакансию с помощью JSON Merge Patch или JSON Patch
+// Патч применяется к документу из редактируемых полей вакансии (см. models.VacancySnapshotFields),
+// в том числе к вложенным ключам additional_fields. Результат проверяется целиком
+func (s *vacancyService) PatchVacancy(id uint, contentType string, body []byte, user *models.User, ifMatch []int) (*dto.VacancyUpdatedResponse, error) {
+	vacancy, err := s.findManagedVacancy(id, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if !versionMatches(ifMatch, vacancy.Version) {
+		return nil, ErrPreconditionFailed
+	}
+
+	before := vacancy.Snapshot()
+
+	patched, err := applyPatch(contentType, body, before.Fields())
+	if err != nil {
+		return nil, err
+	}
+
+	// Результат патча проверяется так же, как тело запроса
+	encoded, err := json.Marshal(patched)
+	if err != nil {
+		return nil, err
+	}
+	var document dto.VacancyDocument
+	if err := dto.Decode(bytes.NewReader(encoded), &document, true); err != nil {
+		return nil, err
+	}
+	document.Title = strings.TrimSpace(document.Title)
+	document.Description = strings.TrimSpace(document.Description)
+	document.Language = strings.ToLower(strings.TrimSpace(document.Language))
+	document.Translations = document.Translations.Normalize()
+	document.Currency = strings.ToUpper(strings.TrimSpace(document.Currency))
+	if err := dto.Validate(&document); err != nil {
+		return nil, err
+	}
+
+	vacancy.Title = document.Title
+	vacancy.Description = document.Description
+	if err := applyLanguage(vacancy, document.Language, document.Translations.Texts()); err != nil {
+		return nil, err
+	}
+	vacancy.SalaryFrom = document.SalaryFrom
+	vacancy.SalaryTo = document.SalaryTo
+	vacancy.Currency = document.Currency
+	vacancy.SalaryGross = document.SalaryGross
+	if err := s.checkSalary(vacancy, &before); err != nil {
+		return nil, err
+	}
+	vacancy.AdditionalFields = document.AdditionalFields
+
+	if !reflect.DeepEqual(document.CompanyID, before.CompanyID) {
+		if err := s.applyCompany(vacancy, document.CompanyID, user); err != nil {
+			return nil, err
+		}
+	}
+
+	publishAt := dto.Nullable[string]{Set: true, Value: document.PublishAt}
+	expiresAt := dto.Nullable[string]{Set: true, Value: document.ExpiresAt}
+	if err := applySchedule(vacancy, publishAt, expiresAt, nil); err != nil {
+		return nil, err
+	}
+
+	return s.saveVacancy(vacancy, before, user, ifMatch)
+}
+
+// saveVacancy сохраняет изменения вакансии с проверкой версии и записывает ревизию в той же транзакции
+func (s *vacancyService) saveVacancy(vacancy *models.Vacancy, before models.VacancySnapshot, user *models.User, ifMatch []int) (*dto.VacancyUpdatedResponse, error) {
+	err := s.repo.Update(vacancy, func(tx *gorm.DB) error {
+		_, err := s.recordRevision(s.revisionRepo.WithTx(tx), vacancy, before, user)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return nil, staleVersionError(ifMatch)
+		}
+		return nil, err
+	}
+
+	return &dto.VacancyUpdatedResponse{
+		Success: true,
+		Version: vacancy.Version,
+		Message: i18n.NewMessage("vacancy.updated", nil),
 	}, nil
 }
 
-// UpdateVacancy обновляет существующую вакансию
-func (s *vacancyService) UpdateVacancy(id uint, data map[string]interface{}) (map[string]interface{}, error) {
+// DeleteVacancy перемещает вакансию в корзину
+// Удалять вакансию может только ее владелец или администратор
+// Если передан ifMatch, версия вакансии должна совпадать с одной из указанных
+func (s *vacancyService) DeleteVacancy(id uint, user *models.User, ifMatch []int) (map[string]interface{}, error) {
 	vacancy, err := s.repo.FindByID(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return map[string]interface{}{
-				"success": false,
-				"message": "Вакансия не найдена",
-			}, nil
+			return nil, ErrNotFound
 		}
 		return nil, err
 	}
 
-	// Обновляем поля если они присутствуют
-	if title, ok := data["title"].(string); ok {
-		vacancy.Title = title
+	if allowed, err := canManageVacancy(s.companyRepo, user, vacancy); err != nil {
+		return nil, err
+	} else if !allowed {
+		return nil, ErrForbidden
 	}
 
-	if description, ok := data["description"].(string); ok {
-		vacancy.Description = description
+	if !versionMatches(ifMatch, vacancy.Version) {
+		return nil, ErrPreconditionFailed
 	}
 
-	if salary, ok := data["salary"].(float64); ok {
-		vacancy.Salary = int(salary)
-	} else if salaryInt, ok := data["salary"].(int); ok {
-		vacancy.Salary = salaryInt
+	if err := s.repo.Delete(vacancy); err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return nil, staleVersionError(ifMatch)
+		}
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"message": i18n.NewMessage("vacancy.deleted", nil),
+	}, nil
+}
+
+// SearchVacancies выполняет полнотекстовый поиск вакансий по основному тексту и переводам
+// Результаты дополнительно ограничиваются фильтром и выводятся по номеру страницы или по курсору.
+// Если указаны fields, из базы читаются и возвращаются только эти поля.
+// Название и описание возвращаются на первом подходящем языке из languages
+func (s *vacancyService) SearchVacancies(query string, filter *dto.VacancyFilterQuery, page dto.PageQuery, sortOrder string, fields []string, languages []string) (map[string]interface{}, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, dto.NewValidationError("q", "required")
 	}
 
-	if additionalFields, ok := data["additional_fields"].(map[string]interface{}); ok {
-		vacancy.AdditionalFields = additionalFields
+	conditions, err := s.vacancyFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	order := repositories.VacancySort{Column: "created_at", Desc: sortOrder != "asc"}
+	if sortOrder == "relevance" {
+		order = repositories.VacancySort{Relevance: true, Desc: true}
+	}
+	request, err := s.pageRequest(page, order)
+	if err != nil {
+		return nil, err
 	}
 
-	// Сохраняем изменения
-	if err := s.repo.Update(vacancy); err != nil {
-		return map[string]interface{}{
-			"success": false,
-			"message": "Ошибка при обновлении вакансии",
-			"error":   err.Error(),
-		}, nil
+	fields = listFields(fields)
+	vacancies, total, err := s.repo.Search(query, conditions, order, request, listColumns(fields))
+	if err != nil {
+		return nil, fmt.Errorf("ошибка поиска: %w", err)
+	}
+
+	result := listResult(vacancies, total, page, request, order, fields, languages)
+	result["query"] = query
+
+	return result, nil
+}
+
+// GetUserVacancies получает вакансии, созданные пользователем
+func (s *vacancyService) GetUserVacancies(user *models.User, page int) (map[string]interface{}, error) {
+	vacancies, total, err := s.repo.FindByUser(user.ID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginatedResult(vacancies, total, page), nil
+}
+
+// ChangeStatus переводит вакансию в новый статус жизненного цикла
+// Переход сохраняется как ревизия вместе с изменением вакансии
+func (s *vacancyService) ChangeStatus(id uint, status string, user *models.User) (map[string]interface{}, error) {
+	vacancy, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if allowed, err := canManageVacancy(s.companyRepo, user, vacancy); err != nil {
+		return nil, err
+	} else if !allowed {
+		return nil, ErrForbidden
+	}
+
+	if !vacancy.CanTransitionTo(status) {
+		return nil, ErrInvalidTransition
+	}
+
+	before := vacancy.Snapshot()
+	if status == models.VacancyStatusPublished {
+		now := time.Now()
+		if vacancy.ExpiresAt != nil && !vacancy.ExpiresAt.After(now) {
+			return nil, dto.NewValidationError("expires_at", "expired")
+		}
+		// Ручная публикация отменяет запланированную дату
+		if vacancy.PublishAt != nil && vacancy.PublishAt.After(now) {
+			vacancy.PublishAt = &now
+		}
+	}
+
+	vacancy.Status = status
+	err = s.repo.Update(vacancy, func(tx *gorm.DB) error {
+		_, err := s.recordRevision(s.revisionRepo.WithTx(tx), vacancy, before, user)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return nil, ErrConflict
+		}
+		return nil, err
 	}
 
 	return map[string]interface{}{
 		"success": true,
-		"message": "Вакансия успешно обновлена",
+		"status":  vacancy.Status,
+		"version": vacancy.Version,
+		"message": i18n.NewMessage("vacancy.status_changed", nil),
 	}, nil
 }
 
-// DeleteVacancy удаляет вакансию
-func (s *vacancyService) DeleteVacancy(id uint) (map[string]interface{}, error) {
-	err := s.repo.Delete(id)
+// ApplySchedule публикует запланированные и закрывает просроченные вакансии
+// Переходы выполняются массовым обновлением и не создают ревизий
+func (s *vacancyService) ApplySchedule(now time.Time) (int64, int64, error) {
+	published, err := s.repo.PublishDue(now)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	expired, err := s.repo.ExpireDue(now)
 	if err != nil {
+		return published, 0, err
+	}
+
+	return published, expired, nil
+}
+
+// GetTrash получает удаленные вакансии
+// Администратор и модератор видят корзину целиком, остальные пользователи
+// свои вакансии и вакансии компаний, в которых они состоят
+func (s *vacancyService) GetTrash(user *models.User, page int) (map[string]interface{}, error) {
+	var userID *uint
+	if !user.HasRole(models.RoleAdmin, models.RoleModerator) {
+		userID = &user.ID
+	}
+
+	vacancies, total, err := s.repo.FindDeleted(userID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return paginatedResult(vacancies, total, page), nil
+}
+
+// RestoreVacancy возвращает вакансию из корзины
+func (s *vacancyService) RestoreVacancy(id uint, user *models.User) (map[string]interface{}, error) {
+	vacancy, err := s.repo.FindDeletedByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if allowed, err := canManageVacancy(s.companyRepo, user, vacancy); err != nil {
+		return nil, err
+	} else if !allowed {
+		return nil, ErrForbidden
+	}
+
+	if err := s.repo.Restore(id); err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return map[string]interface{}{
-				"success": false,
-				"message": "Вакансия не найдена",
-			}, nil
+			return nil, ErrNotFound
 		}
 		return nil, err
 	}
 
 	return map[string]interface{}{
 		"success": true,
-		"message": "Вакансия успешно удалена",
+		"message": i18n.NewMessage("vacancy.restored", nil),
 	}, nil
 }
 
-// SearchVacancies выполняет полнотекстовый поиск вакансий
-func (s *vacancyService) SearchVacancies(query string, page int, sortOrder string) (map[string]interface{}, error) {
-	query = strings.TrimSpace(query)
-	if query == "" {
-		return map[string]interface{}{
-			"success": false,
-			"message": "Поисковый запрос не может быть пустым",
-		}, nil
+// PurgeDeleted окончательно удаляет вакансии, находящиеся в корзине дольше срока хранения
+func (s *vacancyService) PurgeDeleted(before time.Time) (int64, error) {
+	return s.repo.PurgeDeleted(before)
+}
+
+// GetRevisions получает историю изменений вакансии
+func (s *vacancyService) GetRevisions(id uint, user *models.User, page int) (map[string]interface{}, error) {
+	if _, err := s.findManagedVacancy(id, user); err != nil {
+		return nil, err
 	}
 
-	vacancies, total, err := s.repo.Search(query, page, sortOrder)
+	revisions, total, err := s.revisionRepo.FindByVacancy(id, page)
 	if err != nil {
-		return nil, fmt.Errorf("ошибка поиска: %w", err)
+		return nil, err
+	}
+
+	return paginatedResult(revisions, total, page), nil
+}
+
+// GetRevision получает ревизию вакансии по номеру
+func (s *vacancyService) GetRevision(id uint, revision int, user *models.User) (*models.VacancyRevision, error) {
+	if _, err := s.findManagedVacancy(id, user); err != nil {
+		return nil, err
+	}
+
+	return s.findRevision(id, revision)
+}
+
+// DiffRevisions сравнивает две ревизии вакансии
+// Если to не задан, сравнение идет с последней ревизией
+func (s *vacancyService) DiffRevisions(id uint, from int, to int, user *models.User) (map[string]interface{}, error) {
+	if _, err := s.findManagedVacancy(id, user); err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := s.findRevision(id, from)
+	if err != nil {
+		return nil, err
+	}
+
+	var toRevision *models.VacancyRevision
+	if to == 0 {
+		toRevision, err = s.revisionRepo.FindLatest(id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = ErrRevisionNotFound
+		}
+	} else {
+		toRevision, err = s.findRevision(id, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fromFields := fromRevision.Snapshot.Fields()
+	toFields := toRevision.Snapshot.Fields()
+
+	changes := []map[string]interface{}{}
+	for _, field := range models.VacancySnapshotFields {
+		if reflect.DeepEqual(fromFields[field], toFields[field]) {
+			continue
+		}
+		changes = append(changes, map[string]interface{}{
+			"field": field,
+			"from":  fromFields[field],
+			"to":    toFields[field],
+		})
+	}
+
+	return map[string]interface{}{
+		"from":    fromRevision.Revision,
+		"to":      toRevision.Revision,
+		"changes": changes,
+	}, nil
+}
+
+// RevertVacancy возвращает вакансию к состоянию ревизии
+// Откат сохраняется как новая ревизия, история не переписывается
+func (s *vacancyService) RevertVacancy(id uint, revision int, user *models.User) (map[string]interface{}, error) {
+	vacancy, err := s.findManagedVacancy(id, user)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.findRevision(id, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	before := vacancy.Snapshot()
+	vacancy.ApplySnapshot(target.Snapshot)
+
+	// Права на компанию проверяются заново: пользователь мог покинуть ее после ревизии
+	if companyID := target.Snapshot.CompanyID; companyID != nil && (before.CompanyID == nil || *before.CompanyID != *companyID) {
+		if err := s.applyCompany(vacancy, companyID, user); err != nil {
+			return nil, err
+		}
+	}
+
+	// Зарплата и валюта проверяются заново, включая неизменившуюся валюту: курс мог быть удален после ревизии
+	if err := s.checkSalary(vacancy, nil); err != nil {
+		return nil, err
 	}
 
-	pageCount := int(math.Ceil(float64(total) / float64(repositories.PageSize)))
+	var saved *models.VacancyRevision
+	err = s.repo.Update(vacancy, func(tx *gorm.DB) error {
+		var err error
+		saved, err = s.recordRevision(s.revisionRepo.WithTx(tx), vacancy, before, user)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return nil, ErrConflict
+		}
+		return nil, err
+	}
 
 	return map[string]interface{}{
-		"data": vacancies,
-		"pagination": map[string]interface{}{
-			"total":     total,
-			"page":      page,
-			"pageSize":  repositories.PageSize,
-			"pageCount": pageCount,
-		},
-		"query": query,
+		"success":  true,
+		"revision": saved.Revision,
+		"version":  vacancy.Version,
+		"message":  i18n.NewMessage("vacancy.reverted", map[string]string{"revision": strconv.Itoa(target.Revision)}),
 	}, nil
 }
+
+// findManagedVacancy находит вакансию и проверяет права пользователя на нее
+func (s *vacancyService) findManagedVacancy(id uint, user *models.User) (*models.Vacancy, error) {
+	vacancy, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	if allowed, err := canManageVacancy(s.companyRepo, user, vacancy); err != nil {
+		return nil, err
+	} else if !allowed {
+		return nil, ErrForbidden
+	}
+
+	return vacancy, nil
+}
+
+// findRevision находит ревизию вакансии по номеру
+func (s *vacancyService) findRevision(id uint, revision int) (*models.VacancyRevision, error) {
+	result, err := s.revisionRepo.FindByRevision(id, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return result, nil
+}
+
+// recordRevision сохраняет текущее состояние вакансии как новую ревизию через revisions
+// Для вакансий, созданных до появления истории, сначала сохраняется исходное состояние before.
+// Если состояние не изменилось, новая ревизия не создается и возвращается последняя
+func (s *vacancyService) recordRevision(revisions repositories.VacancyRevisionRepository, vacancy *models.Vacancy, before models.VacancySnapshot, user *models.User) (*models.VacancyRevision, error) {
+	latest, err := revisions.FindLatest(vacancy.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		latest = &models.VacancyRevision{VacancyID: vacancy.ID, UserID: vacancy.UserID, Snapshot: before}
+		if err := revisions.Save(latest); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	snapshot := vacancy.Snapshot()
+	if reflect.DeepEqual(latest.Snapshot.Fields(), snapshot.Fields()) {
+		return latest, nil
+	}
+
+	revision := &models.VacancyRevision{VacancyID: vacancy.ID, UserID: &user.ID, Snapshot: snapshot}
+	if err := revisions.Save(revision); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// vacancyFilter проверяет фильтры из строки запроса и преобразует их в условия отбора
+func (s *vacancyService) vacancyFilter(query *dto.VacancyFilterQuery) (repositories.VacancyFilter, error) {
+	filter := repositories.VacancyFilter{}
+	if query == nil {
+		return filter, nil
+	}
+
+	query.Title = strings.TrimSpace(query.Title)
+	if err := dto.Validate(query); err != nil {
+		return filter, err
+	}
+	filter.Title = query.Title
+
+	var salaryMin, salaryMax *int
+	if query.SalaryMin != "" {
+		salary, err := strconv.Atoi(query.SalaryMin)
+		if err != nil {
+			return filter, dto.NewValidationError("salary_min", "number")
+		}
+		salaryMin = &salary
+	}
+	if query.SalaryMax != "" {
+		salary, err := strconv.Atoi(query.SalaryMax)
+		if err != nil {
+			return filter, dto.NewValidationError("salary_max", "number")
+		}
+		salaryMax = &salary
+	}
+	if salaryMin != nil && salaryMax != nil && *salaryMax < *salaryMin {
+		return filter, dto.NewValidationError("salary_max", "range")
+	}
+
+	// Границы зарплаты переводятся в базовую валюту, в которой сравниваются вилки вакансий
+	if salaryMin != nil || salaryMax != nil {
+		currency := strings.ToUpper(query.Currency)
+		if currency == "" {
+			currency = models.BaseCurrency
+		}
+		rate, err := s.exchangeRate(currency)
+		if err != nil {
+			return filter, err
+		}
+		if salaryMin != nil {
+			base := float64(*salaryMin) * rate
+			filter.SalaryMin = &base
+		}
+		if salaryMax != nil {
+			base := float64(*salaryMax) * rate
+			filter.SalaryMax = &base
+		}
+	}
+
+	// Формат дат уже проверен валидатором
+	if query.CreatedAfter != "" {
+		createdAfter, _ := dto.ParseTimestamp(query.CreatedAfter)
+		filter.CreatedAfter = &createdAfter
+	}
+	if query.CreatedBefore != "" {
+		createdBefore, _ := dto.ParseTimestamp(query.CreatedBefore)
+		filter.CreatedBefore = &createdBefore
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedBefore.After(*filter.CreatedAfter) {
+		return filter, dto.NewValidationError("created_before", "range")
+	}
+
+	fields, err := s.fieldConditions(query.Fields)
+	if err != nil {
+		return filter, err
+	}
+	filter.Fields = fields
+
+	return filter, nil
+}
+
+// vacancyListFields поля, которые можно выбрать в списках вакансий
+// Компания и переводы в списках не возвращаются
+var vacancyListFields = []string{
+	"title", "description", "language", "salary_from", "salary_to", "currency", "salary_gross",
+	"additional_fields", "company_id",
+	"status", "version", "publish_at", "expires_at", "created_at", "updated_at",
+}
+
+// salaryFields поля зарплаты, которые выбираются по имени salary
+var salaryFields = []string{"salary_from", "salary_to", "currency", "salary_gross"}
+
+// listFields оставляет из запрошенных полей те, что доступны в списках, nil - все поля
+func listFields(requested []string) []string {
+	if len(requested) == 0 {
+		return nil
+	}
+
+	fields := []string{}
+	for _, field := range requested {
+		expanded := []string{strings.TrimSpace(field)}
+		if expanded[0] == "salary" {
+			expanded = salaryFields
+		}
+		for _, field := range expanded {
+			if slices.Contains(vacancyListFields, field) && !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
+
+// listColumns возвращает колонки, которые нужно прочитать для полей fields
+// Для выбора языка названия и описания дополнительно читается язык вакансии
+func listColumns(fields []string) []string {
+	if fields == nil {
+		return nil
+	}
+	columns := slices.Clone(fields)
+	if (slices.Contains(fields, "title") || slices.Contains(fields, "description")) && !slices.Contains(fields, "language") {
+		columns = append(columns, "language")
+	}
+	return columns
+}
+
+// listResult формирует ответ со страницей списка вакансий
+// При выборке по курсору отбрасывается лишняя вакансия и добавляются курсоры соседних страниц
+func listResult(vacancies []models.Vacancy, total int64, page dto.PageQuery, request repositories.PageRequest, order repositories.VacancySort, fields []string, languages []string) map[string]interface{} {
+	var next, prev *string
+	if request.Keyset {
+		vacancies, next, prev = cursorPage(vacancies, request, order)
+	}
+	localizeVacancies(vacancies, languages)
+
+	var data interface{} = vacancies
+	if fields != nil {
+		items := make([]map[string]interface{}, len(vacancies))
+		for i := range vacancies {
+			items[i] = projectVacancy(&vacancies[i], fields)
+		}
+		data = items
+	}
+
+	if request.Keyset {
+		return cursorResult(data, total, request.PageSize(), next, prev)
+	}
+	return pageResult(data, total, page.Page, request.PageSize())
+}
+
+// projectVacancy возвращает только запрошенные поля вакансии, ID включается всегда
+func projectVacancy(vacancy *models.Vacancy, fields []string) map[string]interface{} {
+	result := make(map[string]interface{})
+	result["id"] = vacancy.ID
+
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		switch field {
+		case "title":
+			result["title"] = vacancy.Title
+		case "description":
+			result["description"] = vacancy.Description
+		case "language":
+			result["language"] = vacancy.Language
+		case "translations":
+			result["translations"] = vacancy.Translations
+		case "salary":
+			result["salary_from"] = vacancy.SalaryFrom
+			result["salary_to"] = vacancy.SalaryTo
+			result["currency"] = vacancy.Currency
+			result["salary_gross"] = vacancy.SalaryGross
+		case "salary_from":
+			result["salary_from"] = vacancy.SalaryFrom
+		case "salary_to":
+			result["salary_to"] = vacancy.SalaryTo
+		case "currency":
+			result["currency"] = vacancy.Currency
+		case "salary_gross":
+			result["salary_gross"] = vacancy.SalaryGross
+		case "additional_fields":
+			result["additional_fields"] = vacancy.AdditionalFields
+		case "company_id":
+			result["company_id"] = vacancy.CompanyID
+		case "company":
+			result["company"] = vacancy.Company
+		case "status":
+			result["status"] = vacancy.Status
+		case "version":
+			result["version"] = vacancy.Version
+		case "publish_at":
+			result["publish_at"] = vacancy.PublishAt
+		case "expires_at":
+			result["expires_at"] = vacancy.ExpiresAt
+		case "created_at":
+			result["created_at"] = vacancy.CreatedAt
+		case "updated_at":
+			result["updated_at"] = vacancy.UpdatedAt
+		}
+	}
+
+	return result
+}
+
+// checkSalary проверяет вилку зарплаты и валюту вакансии
+// Валюта проверяется по таблице курсов, только если она изменилась по сравнению с before (nil - новая вакансия)
+func (s *vacancyService) checkSalary(vacancy *models.Vacancy, before *models.VacancySnapshot) error {
+	if vacancy.SalaryFrom == nil && vacancy.SalaryTo == nil {
+		return dto.NewValidationError("salary_from", "required")
+	}
+	if vacancy.SalaryFrom != nil && vacancy.SalaryTo != nil && *vacancy.SalaryTo < *vacancy.SalaryFrom {
+		return dto.NewValidationError("salary_to", "range")
+	}
+
+	if before != nil && before.Currency == vacancy.Currency {
+		return nil
+	}
+	_, err := s.exchangeRate(vacancy.Currency)
+	return err
+}
+
+// exchangeRate возвращает курс валюты к базовой валюте
+// Если курса нет, возвращается ошибка проверки поля currency
+func (s *vacancyService) exchangeRate(currency string) (float64, error) {
+	rate, err := s.rateRepo.FindByCurrency(currency)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, dto.NewValidationError("currency", "not_supported")
+		}
+		return 0, err
+	}
+	return rate.Rate, nil
+}
+
+// localizeVacancies выбирает язык текста для каждой вакансии списка
+// Переводы в списках не возвращаются, чтобы не увеличивать размер ответа
+func localizeVacancies(vacancies []models.Vacancy, languages []string) {
+	for i := range vacancies {
+		vacancies[i].Localize(languages)
+		vacancies[i].Translations = nil
+	}
+}
+
+// applyLanguage задает язык основного текста вакансии и ее переводы
+// Перевод на язык основного текста не допускается
+func applyLanguage(vacancy *models.Vacancy, language string, texts map[string]models.VacancyText) error {
+	if _, ok := texts[language]; ok {
+		return dto.NewValidationError("translations", "base_language")
+	}
+	vacancy.Language = language
+	vacancy.SetTranslations(texts)
+	return nil
+}
+
+// applyCompany привязывает вакансию к компании, nil отвязывает вакансию
+// Привязать вакансию можно только к компании, в которой состоит пользователь
+func (s *vacancyService) applyCompany(vacancy *models.Vacancy, companyID *uint, user *models.User) error {
+	if companyID == nil {
+		vacancy.CompanyID = nil
+		vacancy.Company = nil
+		return nil
+	}
+
+	id := *companyID
+	if _, err := s.companyRepo.FindByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return dto.NewValidationError("company_id", "not_found")
+		}
+		return err
+	}
+
+	if !user.IsAdmin() {
+		if _, err := s.companyRepo.FindMember(id, user.ID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return dto.NewValidationError("company_id", "not_member")
+			}
+			return err
+		}
+	}
+
+	vacancy.CompanyID = &id
+	vacancy.Company = nil
+	return nil
+}
+
+// applySchedule переносит в вакансию даты публикации и окончания размещения
+// expires_in_days отсчитывается от даты публикации или от текущего момента
+func applySchedule(vacancy *models.Vacancy, publishAt dto.Nullable[string], expiresAt dto.Nullable[string], expiresInDays *int) error {
+	if publishAt.Set {
+		vacancy.PublishAt = parseTime(publishAt.Value)
+	}
+	if expiresAt.Set {
+		vacancy.ExpiresAt = parseTime(expiresAt.Value)
+	}
+
+	if expiresInDays != nil {
+		start := time.Now()
+		if vacancy.PublishAt != nil && vacancy.PublishAt.After(start) {
+			start = *vacancy.PublishAt
+		}
+		expires := start.AddDate(0, 0, *expiresInDays)
+		vacancy.ExpiresAt = &expires
+	}
+
+	if vacancy.PublishAt != nil && vacancy.ExpiresAt != nil && !vacancy.ExpiresAt.After(*vacancy.PublishAt) {
+		return dto.NewValidationError("expires_at", "after_publish_at")
+	}
+	return nil
+}
+
+// parseTime разбирает дату RFC 3339, уже проверенную валидатором
+func parseTime(value *string) *time.Time {
+	if value == nil {
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+// versionMatches проверяет версию вакансии по списку из If-Match
+// nil означает, что клиент не передавал условие
+func versionMatches(ifMatch []int, version int) bool {
+	return ifMatch == nil || slices.Contains(ifMatch, version)
+}
+
+// staleVersionError возвращает ошибку для вакансии, измененной параллельным запросом
+// Клиенту, передавшему If-Match, отвечаем 412, остальным 409
+func staleVersionError(ifMatch []int) error {
+	if ifMatch != nil {
+		return ErrPreconditionFailed
+	}
+	return ErrConflict
+}
+
+// applyPatch разбирает тело патча и применяет его к документу
+func applyPatch(contentType string, body []byte, document map[string]interface{}) (interface{}, error) {
+	switch contentType {
+	case PatchTypeMerge:
+		var mergePatch interface{}
+		if err := dto.Decode(bytes.NewReader(body), &mergePatch, false); err != nil {
+			return nil, err
+		}
+		return patch.MergePatch(document, mergePatch), nil
+	case PatchTypeJSON:
+		var operations []patch.Operation
+		if err := dto.Decode(bytes.NewReader(body), &operations, false); err != nil {
+			return nil, err
+		}
+		result, err := patch.Apply(document, operations)
+		var patchErr *patch.Error
+		if errors.As(err, &patchErr) {
+			fieldError := dto.NewFieldError(patchErr.Path, "patch_"+patchErr.Op, "validation.patch."+patchErr.Reason, map[string]string{
+				"index": strconv.Itoa(patchErr.Index),
+				"op":    patchErr.Op,
+				"value": patchErr.Value,
+			})
+			return nil, &dto.ValidationError{Errors: []dto.FieldError{fieldError}}
+		}
+		return result, err
+	default:
+		fieldError := dto.NewFieldError("", "content_type", "validation.content_type", map[string]string{"value": contentType})
+		return nil, &dto.ValidationError{Errors: []dto.FieldError{fieldError}}
+	}
+}
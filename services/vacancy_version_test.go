@@ -0,0 +1,60 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"vakansii-back-go/dto"
+	"vakansii-back-go/models"
+	"vakansii-back-go/repositories"
+)
+
+// racingVacancies хранилище вакансий, в котором перед каждым обновлением вакансию успевает изменить другой запрос
+type racingVacancies struct {
+	*memoryVacancies
+}
+
+func (r *racingVacancies) Update(vacancy *models.Vacancy, inTx repositories.TxFunc) error {
+	r.vacancies[vacancy.ID].Version++
+	return r.memoryVacancies.Update(vacancy, inTx)
+}
+
+func TestUpdateVacancyVersionCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch []int
+		racing  bool
+		want    error
+	}{
+		{"without If-Match", nil, false, nil},
+		{"current version", []int{2}, false, nil},
+		{"one of versions", []int{1, 2}, false, nil},
+		{"stale version", []int{1}, false, ErrPreconditionFailed},
+		{"empty list", []int{}, false, ErrPreconditionFailed},
+		{"concurrent update without If-Match", nil, true, ErrConflict},
+		{"concurrent update with If-Match", []int{2}, true, ErrPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, vacancies, revisions := newRevisionService(memoryRates{"RUB": 1})
+			if tt.racing {
+				service.repo = &racingVacancies{vacancies}
+			}
+			title := "Updated"
+
+			result, err := service.UpdateVacancy(1, &dto.UpdateVacancyRequest{Title: &title}, revisionOwner, tt.ifMatch)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("UpdateVacancy() error = %v, want %v", err, tt.want)
+			}
+			if tt.want != nil {
+				if vacancies.vacancies[1].Title == title || len(revisions.revisions) != 2 {
+					t.Errorf("vacancy changed after failed update")
+				}
+				return
+			}
+			if result.Version != 3 || vacancies.vacancies[1].Title != title {
+				t.Errorf("UpdateVacancy() version = %d, title = %q, want 3 and %q", result.Version, vacancies.vacancies[1].Title, title)
+			}
+		})
+	}
+}